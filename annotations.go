@@ -0,0 +1,245 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	exampleAttr              = "@example"
+	additionalPropertiesAttr = "@additionalproperties"
+	discriminatorAttr        = "@discriminator"
+	externalDocsAttr         = "@externaldocs"
+)
+
+// typeSpecComments returns the comments of a type declaration, as fillDefinitionDescription reads them.
+func typeSpecComments(typeSpecDef *TypeSpecDef) []*ast.CommentGroup {
+	comments := []*ast.CommentGroup{typeSpecDef.TypeSpec.Doc, typeSpecDef.TypeSpec.Comment}
+	if typeSpecDef.File == nil {
+		return comments
+	}
+
+	for _, astDeclaration := range typeSpecDef.File.Decls {
+		generalDeclaration, ok := astDeclaration.(*ast.GenDecl)
+		if !ok || generalDeclaration.Tok != token.TYPE {
+			continue
+		}
+
+		for _, astSpec := range generalDeclaration.Specs {
+			if astSpec == typeSpecDef.TypeSpec {
+				return append(comments, generalDeclaration.Doc)
+			}
+		}
+	}
+
+	return comments
+}
+
+// definitionAnnotation an annotation of a type declaration.
+type definitionAnnotation struct {
+	// attribute the lower case name of the annotation, e.g. @additionalproperties
+	attribute string
+	// name the name of the annotation as written, without @
+	name  string
+	value string
+}
+
+// definitionAnnotations returns the annotations of a type declaration, such as
+//
+//	// @deprecated
+//	// @title Pet
+//	// @x-visibility internal
+//	// @additionalProperties false
+//	// @discriminator kind dog=Dog,cat=Cat
+//	// @externalDocs https://example.com/docs/pets Pet documentation
+//	// @example {"kind": "dog", "name": "Rex"}
+//
+// a JSON example continues over the next lines until its brackets are balanced.
+func definitionAnnotations(typeSpecDef *TypeSpecDef) []definitionAnnotation {
+	var annotations []definitionAnnotation
+	for _, commentGroup := range typeSpecComments(typeSpecDef) {
+		if commentGroup == nil {
+			continue
+		}
+
+		lines := strings.Split(commentGroup.Text(), "\n")
+		for i := 0; i < len(lines); i++ {
+			fields := FieldsByAnySpace(strings.TrimSpace(lines[i]), 2)
+			if len(fields) == 0 {
+				continue
+			}
+
+			attribute := strings.ToLower(fields[0])
+			switch {
+			case attribute == deprecatedAttr, attribute == titleAttr, attribute == exampleAttr,
+				attribute == additionalPropertiesAttr, attribute == discriminatorAttr, attribute == externalDocsAttr,
+				strings.HasPrefix(attribute, "@x-"):
+			default:
+				continue
+			}
+
+			var value string
+			if len(fields) > 1 {
+				value = strings.TrimSpace(fields[1])
+			}
+			for jsonDepth(value) > 0 && i+1 < len(lines) {
+				i++
+				value += "\n" + lines[i]
+			}
+
+			annotations = append(annotations, definitionAnnotation{attribute: attribute, name: fields[0][1:], value: value})
+		}
+	}
+
+	return annotations
+}
+
+// parseDefinitionAnnotations applies the annotations of a type declaration to its definition,
+// except @discriminator, whose mapping refers to the types embedding it.
+func (parser *Parser) parseDefinitionAnnotations(definition *spec.Schema, typeSpecDef *TypeSpecDef) error {
+	for _, annotation := range definitionAnnotations(typeSpecDef) {
+		if annotation.attribute == discriminatorAttr {
+			continue
+		}
+
+		if IsRefSchema(definition) {
+			// the siblings of a reference are ignored
+			*definition = *(&spec.Schema{}).WithAllOf(*definition)
+		}
+
+		err := parser.parseDefinitionAnnotation(definition, annotation)
+		if err != nil {
+			return fmt.Errorf("%s: %w", typeSpecDef.TypeName(), err)
+		}
+	}
+
+	return nil
+}
+
+// parseDiscriminatorAnnotation applies the @discriminator annotation of a type declaration to its definition,
+// once the definition is parsed, so that the types of the mapping may embed it.
+func (parser *Parser) parseDiscriminatorAnnotation(definition *spec.Schema, typeSpecDef *TypeSpecDef) error {
+	for _, annotation := range definitionAnnotations(typeSpecDef) {
+		if annotation.attribute != discriminatorAttr {
+			continue
+		}
+
+		if IsRefSchema(definition) {
+			*definition = *(&spec.Schema{}).WithAllOf(*definition)
+		}
+
+		err := parser.parseDiscriminator(definition, typeSpecDef, annotation.value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", typeSpecDef.TypeName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (parser *Parser) parseDefinitionAnnotation(definition *spec.Schema, annotation definitionAnnotation) error {
+	value := annotation.value
+
+	switch annotation.attribute {
+	case deprecatedAttr:
+		addExtraProp(definition, "deprecated", true)
+	case titleAttr:
+		definition.Title = value
+	case exampleAttr:
+		var example interface{}
+		if err := json.Unmarshal([]byte(value), &example); err != nil {
+			example = value
+			if len(definition.Type) > 0 {
+				if example, err = defineTypeOfExample(definition.Type[0], "", value); err != nil {
+					return err
+				}
+			}
+		}
+		definition.Example = example
+	case additionalPropertiesAttr:
+		allows, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid @additionalProperties %q, expected true or false", value)
+		}
+		if _, ok := definition.ExtraProps["unevaluatedProperties"]; ok {
+			// a composed struct with StrictObjects
+			addExtraProp(definition, "unevaluatedProperties", allows)
+
+			break
+		}
+		if definition.AdditionalProperties == nil || definition.AdditionalProperties.Schema == nil || !allows {
+			definition.AdditionalProperties = &spec.SchemaOrBool{Allows: allows}
+		}
+	case externalDocsAttr:
+		fields := FieldsByAnySpace(value, 2)
+		if len(fields) == 0 {
+			return fmt.Errorf("missing url of @externalDocs")
+		}
+		definition.ExternalDocs = &spec.ExternalDocumentation{URL: fields[0]}
+		if len(fields) > 1 {
+			definition.ExternalDocs.Description = strings.TrimSpace(fields[1])
+		}
+	default:
+		var extension interface{} = true
+		if value != "" {
+			if err := json.Unmarshal([]byte(value), &extension); err != nil {
+				extension = value
+			}
+		}
+		definition.AddExtension(annotation.name, extension)
+	}
+
+	return nil
+}
+
+// parseDiscriminator parses the property a polymorphic type is told apart by and the mapping of its values
+// to the types, e.g. @discriminator kind dog=Dog,cat=Cat.
+func (parser *Parser) parseDiscriminator(definition *spec.Schema, typeSpecDef *TypeSpecDef, value string) error {
+	fields := FieldsByAnySpace(value, 2)
+	if len(fields) == 0 {
+		return fmt.Errorf("missing property name of @discriminator")
+	}
+
+	discriminator := map[string]interface{}{"propertyName": fields[0]}
+	if len(fields) > 1 {
+		mapping := make(map[string]interface{})
+		for _, pair := range strings.Split(fields[1], ",") {
+			key, typeName, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if !ok {
+				return fmt.Errorf("invalid @discriminator mapping %q, expected value=Type", pair)
+			}
+
+			schema, err := parser.getTypeSchema(typeName, typeSpecDef.File, true)
+			if err != nil {
+				return err
+			}
+			if !IsRefSchema(schema) {
+				return fmt.Errorf("@discriminator mapping %s is not a named type", typeName)
+			}
+
+			mapping[key] = schema.Ref.String()
+		}
+		discriminator["mapping"] = mapping
+	}
+
+	addExtraProp(definition, "discriminator", discriminator)
+
+	return nil
+}
+
+// addExtraProp adds a property of OpenAPI 3 which the Swagger 2 schema lacks, such as deprecated.
+func addExtraProp(schema *spec.Schema, key string, value interface{}) {
+	// copy the properties, they may be shared with another schema
+	extraProps := make(map[string]interface{}, len(schema.ExtraProps)+1)
+	for k, v := range schema.ExtraProps {
+		extraProps[k] = v
+	}
+	extraProps[key] = value
+	schema.ExtraProps = extraProps
+}
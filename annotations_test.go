@@ -0,0 +1,128 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDefinitionAnnotations(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// Pet a pet of the store
+// @title Pet
+// @deprecated
+// @x-visibility internal
+// @x-order 2
+// @additionalProperties false
+// @discriminator kind dog=Dog,cat=Cat
+// @externalDocs https://example.com/docs/pets Pet documentation
+// @example {
+//   "kind": "dog",
+//   "name": "Rex"
+// }
+type Pet struct {
+	Kind string ` + "`json:\"kind\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type Dog struct {
+	Pet
+	Breed string ` + "`json:\"breed\"`" + `
+}
+
+type Cat struct {
+	Pet
+	Lives int ` + "`json:\"lives\"`" + `
+}
+
+// Size of a pet
+// @example small
+type Size string
+
+const (
+	Small Size = "small"
+	Large Size = "large"
+)
+
+// @Success 200 {object} Pet
+// @Success 201 {object} Size
+// @Router /pets [get]
+func Get() {
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	expected := `{
+   "type": "object",
+   "title": "Pet",
+   "properties": {
+      "kind": {
+         "type": "string"
+      },
+      "name": {
+         "type": "string"
+      }
+   },
+   "additionalProperties": false,
+   "x-order": 2,
+   "x-visibility": "internal",
+   "externalDocs": {
+      "description": "Pet documentation",
+      "url": "https://example.com/docs/pets"
+   },
+   "example": {
+      "kind": "dog",
+      "name": "Rex"
+   },
+   "deprecated": true,
+   "discriminator": {
+      "mapping": {
+         "cat": "#/definitions/api.Cat",
+         "dog": "#/definitions/api.Dog"
+      },
+      "propertyName": "kind"
+   }
+}`
+	b, _ := json.MarshalIndent(p.swagger.Definitions["api.Pet"], "", "   ")
+	assert.Equal(t, expected, string(b))
+
+	assert.Equal(t, "small", p.swagger.Definitions["api.Size"].Example)
+	assert.Contains(t, p.swagger.Definitions, "api.Dog")
+	assert.Contains(t, p.swagger.Definitions, "api.Cat")
+}
+
+func TestParseDefinitionAnnotationsError(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @additionalProperties sometimes
+type Pet struct {
+	Name string
+}
+
+// @Success 200 {object} Pet
+// @Router /pets [get]
+func Get() {
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.ErrorContains(t, err, `invalid @additionalProperties "sometimes", expected true or false`)
+}
@@ -0,0 +1,90 @@
+package swag
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// sinceAttr the version of the API an operation is added in, e.g. @Since v1.3.
+	sinceAttr = "@since"
+	// untilAttr the version of the API an operation is removed in, e.g. @Until v2.0.
+	untilAttr = "@until"
+)
+
+const (
+	// sinceTag the version of the API a field is added in, e.g. since:"v1.3".
+	sinceTag = "since"
+	// untilTag the version of the API a field is removed in, e.g. until:"v2.0".
+	untilTag = "until"
+)
+
+// canonicalAPIVersion returns a semantic version with its v prefix, e.g. v1.3 for 1.3.
+func canonicalAPIVersion(version string) (string, error) {
+	version = strings.TrimSpace(version)
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+
+	if !semver.IsValid(version) {
+		return "", fmt.Errorf("invalid version %s, expected a semantic version like v1.3", strings.TrimPrefix(version, "v"))
+	}
+
+	return version, nil
+}
+
+// ParseVersionRangeComment parses @Since or @Until of an operation.
+func (operation *Operation) ParseVersionRangeComment(attribute, commentLine string) error {
+	version, err := canonicalAPIVersion(commentLine)
+	if err != nil {
+		return fmt.Errorf("%s: %w", attribute, err)
+	}
+
+	if attribute == sinceAttr {
+		operation.since = version
+	} else {
+		operation.until = version
+	}
+
+	if operation.since != "" && operation.until != "" && semver.Compare(operation.since, operation.until) >= 0 {
+		return fmt.Errorf("%s %s is not before %s %s", sinceAttr, operation.since, untilAttr, operation.until)
+	}
+
+	return nil
+}
+
+// inAPIVersion whether the API version to generate the docs of is in the range of versions
+// from since, inclusive, to until, exclusive, each of them optional. Without an API version all are.
+func (parser *Parser) inAPIVersion(since, until string) bool {
+	if parser.APIVersion == "" {
+		return true
+	}
+
+	version, err := canonicalAPIVersion(parser.APIVersion)
+	if err != nil {
+		return true
+	}
+
+	return (since == "" || semver.Compare(since, version) <= 0) && (until == "" || semver.Compare(version, until) < 0)
+}
+
+// fieldVersionRange returns the range of versions of a field, by its since and until tags.
+func (ps *tagBaseFieldParser) fieldVersionRange() (since, until string, err error) {
+	if value := ps.tag.Get(sinceTag); value != "" {
+		since, err = canonicalAPIVersion(value)
+		if err != nil {
+			return "", "", fmt.Errorf("%s tag: %w", sinceTag, err)
+		}
+	}
+
+	if value := ps.tag.Get(untilTag); value != "" {
+		until, err = canonicalAPIVersion(value)
+		if err != nil {
+			return "", "", fmt.Errorf("%s tag: %w", untilTag, err)
+		}
+	}
+
+	return since, until, nil
+}
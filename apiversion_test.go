@@ -0,0 +1,95 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAPIVersion(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Account struct {
+	ID int
+	// @since v1.3
+	Email string
+	Name  string ` + "`until:\"2.0\"`" + `
+	FullName string ` + "`since:\"2.0\"`" + `
+}
+
+// @Summary List accounts
+// @Success 200 {array} Account
+// @Router  /accounts [get]
+func ListAccounts() {
+}
+
+// @Summary Search accounts
+// @Since   v1.3
+// @Until   v2.0
+// @Router  /accounts/search [get]
+func SearchAccounts() {
+}
+
+// @Summary Show an account
+// @Since   2.0
+// @Router  /accounts/{id} [get]
+func ShowAccount() {
+}
+`
+	for version, expected := range map[string]struct {
+		paths      []string
+		properties []string
+	}{
+		"":       {[]string{"/accounts", "/accounts/search", "/accounts/{id}"}, []string{"ID", "Email", "Name", "FullName"}},
+		"v1.0.0": {[]string{"/accounts"}, []string{"ID", "Name"}},
+		"1.3":    {[]string{"/accounts", "/accounts/search"}, []string{"ID", "Email", "Name"}},
+		"v2.1":   {[]string{"/accounts", "/accounts/{id}"}, []string{"ID", "Email", "FullName"}},
+	} {
+		p := New(SetAPIVersion(version))
+		p.PropNamingStrategy = PascalCase
+		assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+		_, err := p.packages.ParseTypes()
+		assert.NoError(t, err)
+		assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+		var paths []string
+		for path := range p.swagger.Paths.Paths {
+			paths = append(paths, path)
+		}
+		assert.ElementsMatch(t, expected.paths, paths, version)
+
+		var properties []string
+		for property := range p.swagger.Definitions["api.Account"].Properties {
+			properties = append(properties, property)
+		}
+		assert.ElementsMatch(t, expected.properties, properties, version)
+	}
+}
+
+func TestParseAPIVersionInfo(t *testing.T) {
+	t.Parallel()
+
+	p := New(SetAPIVersion("v1.3"))
+	assert.NoError(t, p.ParseAPI("testdata/simple", mainAPIFile, defaultParseDepth))
+	assert.Equal(t, "v1.3", p.swagger.Info.Version)
+
+	err := New(SetAPIVersion("latest")).ParseAPI("testdata/simple", mainAPIFile, defaultParseDepth)
+	assert.EqualError(t, err, "api version: invalid version latest, expected a semantic version like v1.3")
+}
+
+func TestParseVersionRangeComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	assert.NoError(t, operation.ParseComment("// @Since 1.3", nil))
+	assert.Equal(t, "v1.3", operation.since)
+
+	err := operation.ParseComment("// @Until v1.3.0", nil)
+	assert.EqualError(t, err, "@since v1.3 is not before @until v1.3.0")
+
+	err = NewOperation(nil).ParseComment("// @Since next", nil)
+	assert.EqualError(t, err, "@since: invalid version next, expected a semantic version like v1.3")
+}
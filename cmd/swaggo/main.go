@@ -44,6 +44,16 @@ const (
 	packagePrefixFlag        = "packagePrefix"
 	stateFlag                = "state"
 	parseFuncBodyFlag        = "parseFuncBody"
+	stringEnumsFlag          = "stringEnums"
+	embeddedAllOfFlag        = "embeddedAllOf"
+	openAPIVersionFlag       = "openAPIVersion"
+	parseProtobufFlag        = "parseProtobuf"
+	definitionNamingFlag     = "definitionNaming"
+	strictObjectsFlag        = "strictObjects"
+	inferRoutesFlag          = "inferRoutes"
+	inferHandlerTypesFlag    = "inferHandlerTypes"
+	operationIDNamingFlag    = "operationIdNaming"
+	apiVersionFlag           = "apiVersion"
 )
 
 var initFlags = []cli.Flag{
@@ -191,6 +201,47 @@ var initFlags = []cli.Flag{
 		Name:  parseFuncBodyFlag,
 		Usage: "Parse API info within body of functions in go files, disabled by default",
 	},
+	&cli.BoolFlag{
+		Name:  stringEnumsFlag,
+		Usage: "Document integer enums by the values of their String() method (stringer or switch), disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  embeddedAllOfFlag,
+		Usage: "Compose embedded structs with allOf instead of flattening their fields, disabled by default",
+	},
+	&cli.StringFlag{
+		Name:  openAPIVersionFlag,
+		Value: "3.0",
+		Usage: "Version of the generated OpenAPI document, 3.0 or 3.1",
+	},
+	&cli.BoolFlag{
+		Name:  parseProtobufFlag,
+		Usage: "Document structs generated by protoc-gen-go as protojson encodes them, disabled by default",
+	},
+	&cli.StringFlag{
+		Name:  definitionNamingFlag,
+		Usage: "Naming strategy of definitions: full, short, path:N or a Go template like {{.Package}}{{.TypeName}}",
+	},
+	&cli.BoolFlag{
+		Name:  strictObjectsFlag,
+		Usage: "Set additionalProperties false on the schemas of structs, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  inferRoutesFlag,
+		Usage: "Infer the routes of the operations without @Router from the code registering their handlers, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  inferHandlerTypesFlag,
+		Usage: "Infer the body param and the responses an operation lacks from the body of its handler, disabled by default",
+	},
+	&cli.StringFlag{
+		Name:  operationIDNamingFlag,
+		Usage: "Naming strategy of the operationIds of the operations without @ID: func, receiver, path or a Go template like {{.Receiver}}{{.Func}}",
+	},
+	&cli.StringFlag{
+		Name:  apiVersionFlag,
+		Usage: "Generate the docs of a version of the API, e.g. v1.3, with the operations and the fields whose @Since and @Until include it",
+	},
 }
 
 func initAction(ctx *cli.Context) error {
@@ -202,6 +253,12 @@ func initAction(ctx *cli.Context) error {
 		return fmt.Errorf("not supported %s propertyStrategy", strategy)
 	}
 
+	switch openAPIVersion := ctx.String(openAPIVersionFlag); openAPIVersion {
+	case "3.0", "3.1":
+	default:
+		return fmt.Errorf("not supported %s openAPIVersion", openAPIVersion)
+	}
+
 	leftDelim, rightDelim := "{{", "}}"
 
 	if ctx.IsSet(templateDelimsFlag) {
@@ -274,6 +331,16 @@ func initAction(ctx *cli.Context) error {
 		PackagePrefix:       ctx.String(packagePrefixFlag),
 		State:               ctx.String(stateFlag),
 		ParseFuncBody:       ctx.Bool(parseFuncBodyFlag),
+		StringEnums:         ctx.Bool(stringEnumsFlag),
+		EmbeddedAllOf:       ctx.Bool(embeddedAllOfFlag),
+		OpenAPIVersion:      ctx.String(openAPIVersionFlag),
+		ParseProtobuf:       ctx.Bool(parseProtobufFlag),
+		DefinitionNaming:    ctx.String(definitionNamingFlag),
+		StrictObjects:       ctx.Bool(strictObjectsFlag),
+		InferRoutes:         ctx.Bool(inferRoutesFlag),
+		InferHandlerTypes:   ctx.Bool(inferHandlerTypesFlag),
+		OperationIDNaming:   ctx.String(operationIDNamingFlag),
+		APIVersion:          ctx.String(apiVersionFlag),
 	})
 }
 
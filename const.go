@@ -17,6 +17,9 @@ type ConstVariable struct {
 	Comment string
 	File    *ast.File
 	Pkg     *PackageDefinitions
+
+	// lineComment the text of the trailing line comment, used by stringer -linecomment
+	lineComment string
 }
 
 // VariableName gets the name for this const variable, taking into account comment overrides.
@@ -0,0 +1,306 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// routerPrefixAttr prefixes the paths of the @Router of the operations a package or a receiver type declares defaults for.
+const routerPrefixAttr = "@router.prefix"
+
+// defaultAttributes the annotations which a package doc comment or the doc comment of a receiver type declares
+// as defaults of its operations.
+var defaultAttributes = map[string]bool{
+	routerPrefixAttr: true,
+	tagsAttr:         true,
+	acceptAttr:       true,
+	produceAttr:      true,
+	securityAttr:     true,
+	paramAttr:        true,
+	successAttr:      true,
+	failureAttr:      true,
+	responseAttr:     true,
+	headerAttr:       true,
+	useAttr:          true,
+}
+
+// generalAPIAttributes the annotations of the general API info, which make a package doc comment, as the one of main.go,
+// declare no defaults, and the prefixes of their families.
+var generalAPIAttributes = map[string]bool{
+	titleAttr:               true,
+	versionAttr:             true,
+	tosAttr:                 true,
+	"@host":                 true,
+	"@hoststate":            true,
+	"@basepath":             true,
+	"@schemes":              true,
+	fragmentAttr:            true,
+	"@contact.":             true,
+	"@license.":             true,
+	"@tag.":                 true,
+	"@externaldocs.":        true,
+	"@securitydefinitions.": true,
+	"@query.":               true,
+}
+
+// isGeneralAPIAttribute whether an annotation belongs to the general API info.
+func isGeneralAPIAttribute(attribute string) bool {
+	if family, _, ok := strings.Cut(attribute, "."); ok && generalAPIAttributes[family+"."] {
+		return true
+	}
+
+	return generalAPIAttributes[attribute]
+}
+
+// operationDefaults the defaults of the operations of a package or of the methods of a receiver type.
+type operationDefaults struct {
+	prefix    string
+	operation *Operation
+}
+
+// applyDefaults applies the defaults declared for the package and the receiver type of the handler of an operation,
+// as if they were written before its own annotations: the attributes of its own override them,
+// and its own @Router paths are prefixed by @Router.prefix.
+func (parser *Parser) applyDefaults(operation *Operation) error {
+	if operation.handler.pkgPath == "" {
+		return nil
+	}
+
+	pkgDefaults, err := parser.packageDefaults(operation.handler.pkgPath)
+	if err != nil {
+		return err
+	}
+
+	recvDefaults := &operationDefaults{}
+	if operation.handler.recv != "" && operation.handler.recv != anyReceiver {
+		recvDefaults, err = parser.receiverDefaults(operation.handler.pkgPath, operation.handler.recv)
+		if err != nil {
+			return err
+		}
+	}
+
+	prefix := joinRoutePath(pkgDefaults.prefix, recvDefaults.prefix)
+	for i := range operation.RouterProperties {
+		operation.RouterProperties[i].Path = joinRoutePath(prefix, operation.RouterProperties[i].Path)
+	}
+
+	// the defaults of the receiver type override the ones of the package
+	mergeDefaults(operation, recvDefaults.operation)
+	mergeDefaults(operation, pkgDefaults.operation)
+
+	return nil
+}
+
+// packageDefaults returns the defaults declared by the package doc comments of a package, such as the one of doc.go.
+// A package doc comment with general API info, as the one of main.go, declares none.
+func (parser *Parser) packageDefaults(pkgPath string) (*operationDefaults, error) {
+	if defaults, ok := parser.operationDefaults[pkgPath]; ok {
+		return defaults, nil
+	}
+
+	defaults := &operationDefaults{}
+	if pkg, ok := parser.packages.packages[pkgPath]; ok {
+		fileNames := make([]string, 0, len(pkg.Files))
+		for fileName := range pkg.Files {
+			fileNames = append(fileNames, fileName)
+		}
+		sort.Strings(fileNames)
+
+		for _, fileName := range fileNames {
+			file := pkg.Files[fileName]
+			ok, err := isDefaultsDoc(file.Doc)
+			if err != nil {
+				err = fmt.Errorf("package doc comment of %s: %w", fileName, err)
+				if parser.Strict {
+					return nil, err
+				}
+
+				parser.debug.Printf("warning: %s", err)
+			}
+			if !ok {
+				continue
+			}
+
+			err = parser.parseDefaults(defaults, file.Doc, file)
+			if err != nil {
+				return nil, fmt.Errorf("package doc comment of %s: %w", fileName, err)
+			}
+		}
+	}
+
+	parser.setOperationDefaults(pkgPath, defaults)
+
+	return defaults, nil
+}
+
+// receiverDefaults returns the defaults declared by the doc comment of a receiver type, e.g. type AccountsController struct.
+func (parser *Parser) receiverDefaults(pkgPath, recv string) (*operationDefaults, error) {
+	key := pkgPath + "." + recv
+	if defaults, ok := parser.operationDefaults[key]; ok {
+		return defaults, nil
+	}
+
+	defaults := &operationDefaults{}
+	if typeSpecDef := parser.packages.findTypeSpec(pkgPath, recv); typeSpecDef != nil {
+		for _, doc := range typeSpecComments(typeSpecDef) {
+			ok, err := isDefaultsDoc(doc)
+			if err != nil {
+				err = fmt.Errorf("doc comment of %s: %w", recv, err)
+				if parser.Strict {
+					return nil, err
+				}
+
+				parser.debug.Printf("warning: %s", err)
+			}
+			if !ok {
+				continue
+			}
+
+			err = parser.parseDefaults(defaults, doc, typeSpecDef.File)
+			if err != nil {
+				return nil, fmt.Errorf("doc comment of %s: %w", recv, err)
+			}
+		}
+	}
+
+	parser.setOperationDefaults(key, defaults)
+
+	return defaults, nil
+}
+
+func (parser *Parser) setOperationDefaults(key string, defaults *operationDefaults) {
+	if parser.operationDefaults == nil {
+		parser.operationDefaults = make(map[string]*operationDefaults)
+	}
+
+	parser.operationDefaults[key] = defaults
+}
+
+// parseDefaults parses the default annotations of a doc comment.
+func (parser *Parser) parseDefaults(defaults *operationDefaults, doc *ast.CommentGroup, file *ast.File) error {
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		fields := FieldsByAnySpace(strings.TrimSpace(line), 2)
+		if len(fields) == 0 || !defaultAttributes[strings.ToLower(fields[0])] {
+			continue
+		}
+
+		if strings.ToLower(fields[0]) == routerPrefixAttr {
+			if len(fields) > 1 {
+				defaults.prefix = joinRoutePath(defaults.prefix, strings.TrimSpace(fields[1]))
+			}
+
+			continue
+		}
+
+		if defaults.operation == nil {
+			defaults.operation = NewOperation(parser, SetCodeExampleFilesDirectory(parser.codeExampleFilesDir))
+		}
+
+		err := defaults.operation.ParseComment(line, file)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isDefaultsDoc whether a doc comment declares defaults: it has defaultAttributes and is not the general API info.
+// The @Description of a doc comment describes the API or the schema of a type and is not a default.
+// The error reports the other annotations of a doc comment declaring defaults, which are ignored.
+func isDefaultsDoc(doc *ast.CommentGroup) (bool, error) {
+	if doc == nil {
+		return false, nil
+	}
+
+	var (
+		found       bool
+		unsupported []string
+	)
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+
+		attribute := FieldsByAnySpace(line, 2)[0]
+		switch lowerAttribute := strings.ToLower(attribute); {
+		case defaultAttributes[lowerAttribute]:
+			found = true
+		case isGeneralAPIAttribute(lowerAttribute):
+			return false, nil
+		case lowerAttribute != descriptionAttr:
+			unsupported = append(unsupported, attribute)
+		}
+	}
+
+	if found && len(unsupported) > 0 {
+		return true, fmt.Errorf("%s can not be declared as a default of the operations", strings.Join(unsupported, ", "))
+	}
+
+	return found, nil
+}
+
+// mergeDefaults adds the defaults an operation does not override: its tags, mime types and security
+// replace the default ones, and its params and responses replace the default ones with the same name or status.
+func mergeDefaults(operation *Operation, defaults *Operation) {
+	if defaults == nil {
+		return
+	}
+
+	if len(operation.Tags) == 0 {
+		operation.Tags = append([]string(nil), defaults.Tags...)
+	}
+	if len(operation.Consumes) == 0 {
+		operation.Consumes = append([]string(nil), defaults.Consumes...)
+	}
+	if len(operation.Produces) == 0 {
+		operation.Produces = append([]string(nil), defaults.Produces...)
+	}
+	if operation.Security == nil && defaults.Security != nil {
+		operation.Security = append([]map[string][]string(nil), defaults.Security...)
+	}
+
+	var params []spec.Parameter
+	for _, param := range defaults.Parameters {
+		// an operation has one body
+		if !hasParam(operation.Parameters, param.Name, param.In) && (param.In != "body" || !hasParamIn(operation, "body")) {
+			params = append(params, param)
+		}
+	}
+	operation.Parameters = append(params, operation.Parameters...)
+
+	if defaults.Responses == nil {
+		return
+	}
+	if operation.Responses == nil {
+		operation.Responses = &spec.Responses{}
+	}
+	if operation.Responses.Default == nil && defaults.Responses.Default != nil {
+		response := *defaults.Responses.Default
+		operation.Responses.Default = &response
+	}
+	for code, response := range defaults.Responses.StatusCodeResponses {
+		if operation.Responses.StatusCodeResponses == nil {
+			operation.Responses.StatusCodeResponses = make(map[int]spec.Response)
+		}
+		if _, ok := operation.Responses.StatusCodeResponses[code]; !ok {
+			operation.Responses.StatusCodeResponses[code] = response
+		}
+	}
+}
+
+// hasParam whether params have a param with a name in a location.
+func hasParam(params []spec.Parameter, name, in string) bool {
+	for _, param := range params {
+		if param.Name == name && param.In == in {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,245 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOperationDefaults(t *testing.T) {
+	t.Parallel()
+
+	docSrc := `
+// Package controller serves the accounts.
+//
+// @Router.prefix /api
+// @Produce json
+// @Tags misc
+// @Failure 500 {string} string "internal error"
+package controller
+`
+	controllerSrc := `
+package controller
+
+// AccountsController serves the accounts.
+//
+// @Router.prefix /accounts
+// @Tags accounts
+// @Security ApiKeyAuth
+// @Param X-Request-ID header string false "request id"
+type AccountsController struct{}
+
+// @Summary Show an account
+// @Param id path int true "Account ID"
+// @Success 200 {string} string
+// @Router /{id} [get]
+func (c *AccountsController) ShowAccount() {
+}
+
+// @Summary Delete an account
+// @Tags admin
+// @Security
+// @Failure 500 {object} string "could not delete"
+// @Router /{id} [delete]
+func (c *AccountsController) DeleteAccount() {
+}
+
+// @Summary Health
+// @Success 200 {string} string
+// @Router /health [get]
+func Health() {
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("controller", "controller/doc.go", docSrc, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("controller", "controller/accounts.go", controllerSrc, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	expected := `{
+   "/api/accounts/{id}": {
+      "get": {
+         "security": [
+            {
+               "ApiKeyAuth": []
+            }
+         ],
+         "produces": [
+            "application/json"
+         ],
+         "tags": [
+            "accounts"
+         ],
+         "summary": "Show an account",
+         "parameters": [
+            {
+               "type": "string",
+               "description": "request id",
+               "name": "X-Request-ID",
+               "in": "header"
+            },
+            {
+               "type": "integer",
+               "description": "Account ID",
+               "name": "id",
+               "in": "path",
+               "required": true
+            }
+         ],
+         "responses": {
+            "200": {
+               "description": "OK",
+               "schema": {
+                  "type": "string"
+               }
+            },
+            "500": {
+               "description": "internal error",
+               "schema": {
+                  "type": "string"
+               }
+            }
+         }
+      },
+      "delete": {
+         "security": [],
+         "produces": [
+            "application/json"
+         ],
+         "tags": [
+            "admin"
+         ],
+         "summary": "Delete an account",
+         "parameters": [
+            {
+               "type": "string",
+               "description": "request id",
+               "name": "X-Request-ID",
+               "in": "header"
+            },
+            {
+               "type": "string",
+               "name": "id",
+               "in": "path",
+               "required": true
+            }
+         ],
+         "responses": {
+            "500": {
+               "description": "could not delete",
+               "schema": {
+                  "type": "string"
+               }
+            }
+         }
+      }
+   },
+   "/api/health": {
+      "get": {
+         "produces": [
+            "application/json"
+         ],
+         "tags": [
+            "misc"
+         ],
+         "summary": "Health",
+         "responses": {
+            "200": {
+               "description": "OK",
+               "schema": {
+                  "type": "string"
+               }
+            },
+            "500": {
+               "description": "internal error",
+               "schema": {
+                  "type": "string"
+               }
+            }
+         }
+      }
+   }
+}`
+	b, err := json.MarshalIndent(p.swagger.Paths.Paths, "", "   ")
+	assert.NoError(t, err)
+	assert.JSONEq(t, expected, string(b))
+}
+
+func TestIsDefaultsDoc(t *testing.T) {
+	t.Parallel()
+
+	src := `
+// @title Accounts API
+// @version 1.0
+// @accept json
+package main
+
+// Controller is not annotated.
+type Controller struct{}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("main", "main.go", src, ParseAll))
+
+	for _, file := range p.packages.packages["main"].Files {
+		ok, err := isDefaultsDoc(file.Doc)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	}
+
+	defaults, err := p.packageDefaults("main")
+	assert.NoError(t, err)
+	assert.Nil(t, defaults.operation)
+	assert.Empty(t, defaults.prefix)
+
+	defaults, err = p.receiverDefaults("main", "Controller")
+	assert.NoError(t, err)
+	assert.Nil(t, defaults.operation)
+}
+
+func TestParseOperationDefaultsUnsupported(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package controller
+
+// AccountsController serves the accounts.
+//
+// @Description The accounts of the users
+// @Tags accounts
+// @Failure 500 {string} string "internal error"
+// @Header 500 {string} X-Request-ID "request id"
+// @Summary Accounts
+// @Deprecated
+type AccountsController struct{}
+
+// @Summary Show an account
+// @Success 200 {string} string
+// @Router /accounts/{id} [get]
+func (c *AccountsController) ShowAccount() {
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("controller", "controller/accounts.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	// the annotations which are not defaults are ignored, the defaults are kept
+	defaults, err := p.receiverDefaults("controller", "AccountsController")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"accounts"}, defaults.operation.Tags)
+	assert.Empty(t, defaults.operation.Summary)
+	assert.False(t, defaults.operation.Deprecated)
+
+	response := defaults.operation.Responses.StatusCodeResponses[500]
+	assert.Equal(t, "request id", response.Headers["X-Request-ID"].Description)
+
+	p = New(SetStrict(true))
+	assert.NoError(t, p.packages.ParseFile("controller", "controller/accounts.go", src, ParseAll))
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	_, err = p.receiverDefaults("controller", "AccountsController")
+	assert.EqualError(t, err, "doc comment of AccountsController: @Summary, @Deprecated can not be declared as a default of the operations")
+}
@@ -0,0 +1,173 @@
+package swag
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/spec"
+)
+
+// The extensions of a deprecated operation declaring its lifecycle.
+const (
+	deprecatedSinceExtension = "x-deprecated-since"
+	sunsetExtension          = "x-sunset"
+	replacedByExtension      = "x-replaced-by"
+)
+
+// The response headers of a deprecated operation, https://www.rfc-editor.org/rfc/rfc9745 and https://www.rfc-editor.org/rfc/rfc8594.
+const (
+	deprecationHeader = "Deprecation"
+	sunsetHeader      = "Sunset"
+)
+
+// deprecation the lifecycle of a deprecated operation, declared by @Deprecated since=2025-01-01 sunset=2026-06-30 replacement=getAccountV2.
+type deprecation struct {
+	since       time.Time
+	sunset      time.Time
+	replacement string
+}
+
+// ParseDeprecatedComment deprecates the operation, and parses its lifecycle: the date it is deprecated since,
+// the date it is removed on and the operationId of the operation replacing it, each of them optional.
+// Any other text, e.g. @Deprecated use getAccountV2 instead, is ignored with a warning, or is an error in Strict mode.
+func (operation *Operation) ParseDeprecatedComment(commentLine string) error {
+	operation.Deprecate()
+
+	fields := strings.Fields(commentLine)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	lifecycle := &deprecation{}
+	var ignored []string
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			ignored = append(ignored, field)
+
+			continue
+		}
+
+		var err error
+		switch strings.ToLower(key) {
+		case "since":
+			lifecycle.since, err = parseDeprecationDate(key, value)
+			operation.AddExtension(deprecatedSinceExtension, value)
+		case "sunset":
+			lifecycle.sunset, err = parseDeprecationDate(key, value)
+			operation.AddExtension(sunsetExtension, value)
+		case "replacement":
+			if value == "" {
+				err = fmt.Errorf("%s needs an operationId of replacement", deprecatedAttr)
+			}
+			lifecycle.replacement = value
+			operation.AddExtension(replacedByExtension, value)
+		default:
+			ignored = append(ignored, field)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(ignored) > 0 {
+		err := fmt.Errorf("%s ignores %q, expected since=YYYY-MM-DD, sunset=YYYY-MM-DD or replacement=operationId",
+			deprecatedAttr, strings.Join(ignored, " "))
+		if operation.parser.Strict {
+			return err
+		}
+		operation.parser.debug.Printf("warning: %s", err)
+	}
+
+	if !lifecycle.since.IsZero() && !lifecycle.sunset.IsZero() && lifecycle.sunset.Before(lifecycle.since) {
+		return fmt.Errorf("sunset %s of %s is before since %s",
+			lifecycle.sunset.Format(time.DateOnly), deprecatedAttr, lifecycle.since.Format(time.DateOnly))
+	}
+
+	if len(ignored) < len(fields) {
+		operation.deprecation = lifecycle
+	}
+
+	return nil
+}
+
+// parseDeprecationDate parses a date of @Deprecated, e.g. 2026-06-30.
+func parseDeprecationDate(key, value string) (time.Time, error) {
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s date %s of %s, expected YYYY-MM-DD", key, value, deprecatedAttr)
+	}
+
+	return date, nil
+}
+
+// applyDeprecation documents the Deprecation and Sunset headers of the responses of an operation with a lifecycle,
+// unless the responses declare them, and warns if the sunset date of the operation has passed.
+func (parser *Parser) applyDeprecation(operation *Operation) {
+	lifecycle := operation.deprecation
+	if lifecycle == nil {
+		return
+	}
+
+	if !lifecycle.sunset.IsZero() && lifecycle.sunset.Before(time.Now()) {
+		var routes []string
+		for _, routeProperties := range operation.RouterProperties {
+			routes = append(routes, routeProperties.HTTPMethod+" "+routeProperties.Path)
+		}
+		parser.debug.Printf("warning: sunset date %s of %s has passed", lifecycle.sunset.Format(time.DateOnly), strings.Join(routes, ", "))
+	}
+
+	headers := map[string]spec.Header{deprecationHeader: lifecycle.deprecationHeader()}
+	if !lifecycle.sunset.IsZero() {
+		headers[sunsetHeader] = lifecycle.sunsetHeader()
+	}
+
+	if operation.Responses == nil {
+		return
+	}
+	if operation.Responses.Default != nil {
+		addHeaders(operation.Responses.Default, headers)
+	}
+	for code, response := range operation.Responses.StatusCodeResponses {
+		addHeaders(&response, headers)
+		operation.Responses.StatusCodeResponses[code] = response
+	}
+}
+
+// deprecationHeader the Deprecation header, a structured date of the time the operation is deprecated since, e.g. @1735689600.
+func (lifecycle *deprecation) deprecationHeader() spec.Header {
+	header := newHeaderSpec(STRING, "The operation is deprecated")
+	if !lifecycle.since.IsZero() {
+		header.Description += " since " + lifecycle.since.Format(time.DateOnly)
+		header.Example = "@" + strconv.FormatInt(lifecycle.since.Unix(), 10)
+	}
+	if lifecycle.replacement != "" {
+		header.Description += ", use " + lifecycle.replacement + " instead"
+	}
+
+	return header
+}
+
+// sunsetHeader the Sunset header, the HTTP date the operation is removed on, e.g. Tue, 30 Jun 2026 00:00:00 GMT.
+func (lifecycle *deprecation) sunsetHeader() spec.Header {
+	header := newHeaderSpec(STRING, "The operation is removed on "+lifecycle.sunset.Format(time.DateOnly))
+	header.Example = lifecycle.sunset.Format(http.TimeFormat)
+
+	return header
+}
+
+// addHeaders adds the headers a response does not declare.
+func addHeaders(response *spec.Response, headers map[string]spec.Header) {
+	for name, header := range headers {
+		if _, ok := response.Headers[name]; ok {
+			continue
+		}
+		if response.Headers == nil {
+			response.Headers = make(map[string]spec.Header)
+		}
+		response.Headers[name] = header
+	}
+}
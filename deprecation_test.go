@@ -0,0 +1,120 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDeprecatedLifecycle(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @Summary Show an account
+// @Deprecated since=2025-01-01 sunset=2026-06-30 replacement=getAccountV2
+// @Success 200 {string} string
+// @Failure 404 {string} string "not found"
+// @Header  404 {string} Sunset "own"
+// @Router  /accounts/{id} [get]
+func ShowAccount() {
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	expected := `{
+   "summary": "Show an account",
+   "deprecated": true,
+   "parameters": [
+      {
+         "type": "string",
+         "name": "id",
+         "in": "path",
+         "required": true
+      }
+   ],
+   "responses": {
+      "200": {
+         "description": "OK",
+         "schema": {
+            "type": "string"
+         },
+         "headers": {
+            "Deprecation": {
+               "type": "string",
+               "example": "@1735689600",
+               "description": "The operation is deprecated since 2025-01-01, use getAccountV2 instead"
+            },
+            "Sunset": {
+               "type": "string",
+               "example": "Tue, 30 Jun 2026 00:00:00 GMT",
+               "description": "The operation is removed on 2026-06-30"
+            }
+         }
+      },
+      "404": {
+         "description": "not found",
+         "schema": {
+            "type": "string"
+         },
+         "headers": {
+            "Deprecation": {
+               "type": "string",
+               "example": "@1735689600",
+               "description": "The operation is deprecated since 2025-01-01, use getAccountV2 instead"
+            },
+            "Sunset": {
+               "type": "string",
+               "description": "own"
+            }
+         }
+      }
+   },
+   "x-deprecated-since": "2025-01-01",
+   "x-replaced-by": "getAccountV2",
+   "x-sunset": "2026-06-30"
+}`
+	b, err := json.MarshalIndent(p.swagger.Paths.Paths["/accounts/{id}"].Get, "", "   ")
+	assert.NoError(t, err)
+	assert.JSONEq(t, expected, string(b))
+}
+
+func TestParseDeprecatedComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	assert.NoError(t, operation.ParseComment("// @Deprecated", nil))
+	assert.True(t, operation.Deprecated)
+	assert.Nil(t, operation.deprecation)
+	assert.Empty(t, operation.Extensions)
+
+	for comment, expected := range map[string]string{
+		"// @Deprecated sunset=30.06.2026":                  "invalid sunset date 30.06.2026 of @deprecated, expected YYYY-MM-DD",
+		"// @Deprecated replacement=":                       "@deprecated needs an operationId of replacement",
+		"// @Deprecated since=2026-01-01 sunset=2025-01-01": "sunset 2025-01-01 of @deprecated is before since 2026-01-01",
+	} {
+		assert.EqualError(t, NewOperation(nil).ParseComment(comment, nil), expected)
+	}
+
+	// free text is ignored with a warning
+	logger := &testLogger{}
+	operation = NewOperation(New(SetDebugger(logger)))
+	assert.NoError(t, operation.ParseComment("// @Deprecated use getAccountV2 instead", nil))
+	assert.True(t, operation.Deprecated)
+	assert.Nil(t, operation.deprecation)
+	assert.Equal(t, []string{`warning: @deprecated ignores "use getAccountV2 instead", ` +
+		"expected since=YYYY-MM-DD, sunset=YYYY-MM-DD or replacement=operationId"}, logger.Messages)
+
+	operation = NewOperation(New(SetDebugger(&testLogger{})))
+	assert.NoError(t, operation.ParseComment("// @Deprecated sunset=2026-06-30 until=2026-06-30, see the changelog", nil))
+	assert.NotNil(t, operation.deprecation)
+	assert.Equal(t, "2026-06-30", operation.Extensions["x-sunset"])
+
+	err := NewOperation(New(SetStrict(true))).ParseComment("// @Deprecated use getAccountV2 instead", nil)
+	assert.EqualError(t, err, `@deprecated ignores "use getAccountV2 instead", `+
+		"expected since=YYYY-MM-DD, sunset=YYYY-MM-DD or replacement=operationId")
+}
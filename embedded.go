@@ -0,0 +1,202 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	// embedAllOf composes an embedded struct with allOf.
+	embedAllOf = "allOf"
+	// embedFlatten flattens the fields of an embedded struct into its parent.
+	embedFlatten = "flatten"
+)
+
+// promotedField a property of a struct schema, with the depth it is promoted from through embedded structs.
+type promotedField struct {
+	name     string
+	schema   spec.Schema
+	required bool
+
+	// depth 0 for the fields declared by the struct itself, 1 for the fields of an embedded struct and so on
+	depth int
+	// tagged whether the name of the field is set by a json or form tag
+	tagged bool
+	// embeddedIn the embedded type the field is promoted from
+	embeddedIn string
+}
+
+func (field promotedField) String() string {
+	if field.embeddedIn == "" {
+		return "the struct"
+	}
+	return field.embeddedIn
+}
+
+// dominantField picks the field promoted under name following Go's rules as applied by encoding/json:
+// the shallowest field wins, among fields at the same depth a single one named by a tag wins,
+// otherwise the name is ambiguous and the property is omitted.
+func (parser *Parser) dominantField(name string, fields []promotedField) (*promotedField, error) {
+	if len(fields) == 1 {
+		return &fields[0], nil
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].depth < fields[j].depth
+	})
+
+	dominant := fields[:1]
+	for _, field := range fields[1:] {
+		if field.depth == fields[0].depth {
+			dominant = append(dominant, field)
+		}
+	}
+
+	if len(dominant) > 1 {
+		var tagged []promotedField
+		for _, field := range dominant {
+			if field.tagged {
+				tagged = append(tagged, field)
+			}
+		}
+		if len(tagged) == 1 {
+			dominant = tagged
+		}
+	}
+
+	if len(dominant) > 1 {
+		sources := make([]string, 0, len(dominant))
+		for _, field := range dominant {
+			sources = append(sources, field.String())
+		}
+
+		err := fmt.Errorf("property %s is ambiguous, it is declared by %s at the same depth", name, strings.Join(sources, ", "))
+		if parser.Strict {
+			return nil, err
+		}
+
+		parser.debug.Printf("warning: %s, omitting it\n", err)
+
+		return nil, nil
+	}
+
+	for _, field := range fields {
+		if field.depth > dominant[0].depth {
+			parser.debug.Printf("property %s of %s is shadowed by %s", name, field, dominant[0])
+		}
+	}
+
+	return &dominant[0], nil
+}
+
+// parseEmbeddedAllOf returns the reference to an embedded struct which is composed with allOf instead of flattened,
+// as set by the swaggerembed tag of the field or else by the parser option.
+func (parser *Parser) parseEmbeddedAllOf(file *ast.File, field *ast.Field) (*spec.Schema, error) {
+	if len(field.Names) > 0 {
+		return nil, nil
+	}
+
+	ps := parser.fieldParserFactory(parser, field)
+	if ps.ShouldSkip() {
+		return nil, nil
+	}
+
+	switch embed := ps.FirstTagValue(swaggerEmbedTag); embed {
+	case embedAllOf:
+	case embedFlatten:
+		return nil, nil
+	case "":
+		if !parser.EmbeddedAllOf {
+			return nil, nil
+		}
+	default:
+		return nil, fmt.Errorf("invalid swaggerembed tag value %q, expected %s or %s", embed, embedAllOf, embedFlatten)
+	}
+
+	fieldNames, err := ps.FieldNames()
+	if err != nil || len(fieldNames) > 0 {
+		return nil, nil
+	}
+
+	typeName, err := getFieldType(file, field.Type, nil)
+	if err != nil {
+		return nil, nil
+	}
+
+	schema, err := parser.getTypeSchema(typeName, file, true)
+	if err != nil || schema.Ref.GetURL() == nil {
+		// let parseStructField report the error or flatten a simple type
+		return nil, nil
+	}
+
+	if parser.StrictObjects {
+		parser.allOfMembers[schema.Ref.String()] = struct{}{}
+	}
+
+	return schema, nil
+}
+
+// openAllOfMembers lets the definitions of the structs embedded with allOf allow additional properties with StrictObjects,
+// additionalProperties false would reject the properties of the structs embedding them. The composed schema
+// of an embedding struct gets unevaluatedProperties false instead, which sees the properties of all its subschemas.
+func (parser *Parser) openAllOfMembers() {
+	for ref := range parser.allOfMembers {
+		name := strings.TrimPrefix(ref, "#/definitions/")
+		definition, ok := parser.swagger.Definitions[name]
+		if !ok || definition.AdditionalProperties == nil ||
+			definition.AdditionalProperties.Allows || definition.AdditionalProperties.Schema != nil {
+			continue
+		}
+
+		parser.debug.Printf("Definition %s is embedded with allOf, it allows additional properties", name)
+		definition.AdditionalProperties = nil
+		parser.swagger.Definitions[name] = definition
+	}
+}
+
+// flattenAllOf merges the properties of a struct schema composed with allOf,
+// the own properties listed last shadow the properties of the embedded structs.
+func (parser *Parser) flattenAllOf(schema *spec.Schema) *spec.Schema {
+	if len(schema.AllOf) == 0 {
+		return schema
+	}
+
+	flat := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:       []string{OBJECT},
+			Properties: make(map[string]spec.Schema),
+			Required:   slices.Clone(schema.Required),
+		},
+	}
+	for name, prop := range schema.Properties {
+		flat.Properties[name] = prop
+	}
+
+	for i := range schema.AllOf {
+		part := &schema.AllOf[i]
+		if part.Ref.GetURL() != nil {
+			if part = parser.getUnderlyingSchema(part); part == nil {
+				continue
+			}
+		}
+
+		part = parser.flattenAllOf(part)
+		for name, prop := range part.Properties {
+			flat.Properties[name] = prop
+		}
+		for _, name := range part.Required {
+			if !slices.Contains(flat.Required, name) {
+				flat.Required = append(flat.Required, name)
+			}
+		}
+	}
+
+	sort.Strings(flat.Required)
+
+	return flat
+}
@@ -0,0 +1,183 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEmbeddedAllOf(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Base struct {
+	ID int ` + "`json:\"id\" binding:\"required\"`" + `
+}
+
+type Audit struct {
+	CreatedBy string ` + "`json:\"createdBy\"`" + `
+}
+
+type Account struct {
+	Base ` + "`swaggerembed:\"allOf\"`" + `
+	Audit
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type Admin struct {
+	Account
+	Role string ` + "`json:\"role\"`" + `
+}
+
+// @Success 200 {object} Account
+// @Success 201 {object} Admin
+// @Router /account [get]
+func Get() {
+}
+`
+	expected := `{
+   "api.Account": {
+      "allOf": [
+         {
+            "$ref": "#/definitions/api.Base"
+         },
+         {
+            "type": "object",
+            "properties": {
+               "createdBy": {
+                  "type": "string"
+               },
+               "name": {
+                  "type": "string"
+               }
+            }
+         }
+      ]
+   },
+   "api.Admin": {
+      "type": "object",
+      "required": [
+         "id"
+      ],
+      "properties": {
+         "createdBy": {
+            "type": "string"
+         },
+         "id": {
+            "type": "integer"
+         },
+         "name": {
+            "type": "string"
+         },
+         "role": {
+            "type": "string"
+         }
+      }
+   },
+   "api.Base": {
+      "type": "object",
+      "required": [
+         "id"
+      ],
+      "properties": {
+         "id": {
+            "type": "integer"
+         }
+      }
+   }
+}`
+
+	p := New()
+	err := p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	assert.NoError(t, err)
+
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	out, err := json.MarshalIndent(p.swagger.Definitions, "", "   ")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(out))
+
+	p = New(SetEmbeddedAllOf(true))
+	err = p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	assert.NoError(t, err)
+
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	admin := p.swagger.Definitions["api.Admin"]
+	assert.Len(t, admin.AllOf, 2)
+	assert.Equal(t, "#/definitions/api.Account", admin.AllOf[0].Ref.String())
+	assert.Contains(t, admin.AllOf[1].Properties, "role")
+	assert.Len(t, p.swagger.Definitions["api.Account"].AllOf, 3)
+}
+
+func TestParseEmbeddedShadowing(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Name struct {
+	Name  string ` + "`json:\"name\"`" + `
+	Label string ` + "`json:\"label\"`" + `
+}
+
+type Title struct {
+	Title string
+	Label string ` + "`json:\"label\"`" + `
+}
+
+type Nickname struct {
+	Title string ` + "`json:\"title\"`" + `
+}
+
+type Account struct {
+	Name
+	Title
+	Nickname
+	Name string ` + "`json:\"name\" example:\"own\"`" + `
+}
+
+// @Success 200 {object} Account
+// @Router /account [get]
+func Get() {
+}
+`
+	p := New()
+	err := p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	assert.NoError(t, err)
+
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	account := p.swagger.Definitions["api.Account"]
+	// the own field shadows the embedded one
+	assert.Equal(t, "own", account.Properties["name"].Example)
+	// both embedded fields are tagged at the same depth
+	assert.NotContains(t, account.Properties, "label")
+	// only the field of Nickname is tagged
+	assert.Contains(t, account.Properties, "title")
+
+	p = New(SetStrict(true))
+	err = p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	assert.NoError(t, err)
+
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.ErrorContains(t, err, "property label is ambiguous")
+}
@@ -1,9 +1,18 @@
 package swag
 
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
 const (
 	enumVarNamesExtension     = "x-enum-varnames"
 	enumCommentsExtension     = "x-enum-comments"
 	enumDescriptionsExtension = "x-enum-descriptions"
+
+	stringerHeaderPrefix = `Code generated by "stringer `
 )
 
 // EnumValue a model to record an enum consts variable
@@ -11,4 +20,162 @@ type EnumValue struct {
 	key     string
 	Value   interface{}
 	Comment string
+
+	// StringValue the text printed by the String() method of the enum type, if known
+	StringValue string
+}
+
+// stringEnumValues replaces the values of enums with the text printed by their String() method,
+// enums without a known string value are dropped and their names returned.
+func stringEnumValues(enums []EnumValue) (result []EnumValue, dropped []string) {
+	for _, enum := range enums {
+		if enum.StringValue == "" {
+			dropped = append(dropped, enum.key)
+			continue
+		}
+		enum.Value = enum.StringValue
+		result = append(result, enum)
+	}
+	return result, dropped
+}
+
+// stringerCommand the arguments of a `go:generate stringer` command which generated a file.
+type stringerCommand struct {
+	types       map[string]struct{}
+	trimPrefix  string
+	lineComment bool
+}
+
+// parseStringerCommand parses the header of a file generated by stringer,
+// e.g. // Code generated by "stringer -type=Status -linecomment"; DO NOT EDIT.
+func parseStringerCommand(file *ast.File) *stringerCommand {
+	for _, commentGroup := range file.Comments {
+		if commentGroup.Pos() >= file.Package {
+			break
+		}
+		for _, comment := range commentGroup.List {
+			text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+			if !strings.HasPrefix(text, stringerHeaderPrefix) {
+				continue
+			}
+			text = text[len(stringerHeaderPrefix):]
+			if end := strings.IndexByte(text, '"'); end >= 0 {
+				text = text[:end]
+			}
+
+			command := &stringerCommand{types: make(map[string]struct{})}
+			args := strings.Fields(text)
+			for i := 0; i < len(args); i++ {
+				name, value, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+				if !hasValue && name != "linecomment" && i+1 < len(args) {
+					i++
+					value = args[i]
+				}
+				switch name {
+				case "type":
+					for _, typeName := range strings.Split(value, ",") {
+						command.types[strings.TrimSpace(typeName)] = struct{}{}
+					}
+				case "trimprefix":
+					command.trimPrefix = value
+				case "linecomment":
+					command.lineComment = value == "" || value == "true"
+				}
+			}
+			return command
+		}
+	}
+	return nil
+}
+
+// values evaluates the text stringer prints for each const of typeName in pkg.
+func (command *stringerCommand) values(pkg *PackageDefinitions, typeName string) map[string]string {
+	if _, ok := command.types[typeName]; !ok {
+		return nil
+	}
+
+	values := make(map[string]string)
+	for _, constVar := range pkg.OrderedConst {
+		ident, ok := constVar.Type.(*ast.Ident)
+		if !ok || ident.Name != typeName || constVar.Name.Name == "_" {
+			continue
+		}
+		if command.lineComment && constVar.lineComment != "" {
+			values[constVar.Name.Name] = constVar.lineComment
+			continue
+		}
+		values[constVar.Name.Name] = strings.TrimPrefix(constVar.Name.Name, command.trimPrefix)
+	}
+	return values
+}
+
+// stringMethodReceiver returns the receiver type name of a `func (T) String() string` declaration.
+func stringMethodReceiver(funcDecl *ast.FuncDecl) (string, bool) {
+	if funcDecl.Name.Name != "String" || funcDecl.Recv == nil || len(funcDecl.Recv.List) != 1 || funcDecl.Body == nil {
+		return "", false
+	}
+	if len(funcDecl.Type.Params.List) != 0 || funcDecl.Type.Results == nil || len(funcDecl.Type.Results.List) != 1 {
+		return "", false
+	}
+	if result, ok := funcDecl.Type.Results.List[0].Type.(*ast.Ident); !ok || result.Name != "string" {
+		return "", false
+	}
+
+	recvType := funcDecl.Recv.List[0].Type
+	if star, ok := recvType.(*ast.StarExpr); ok {
+		recvType = star.X
+	}
+	ident, ok := recvType.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	return ident.Name, true
+}
+
+// switchStringValues collects the string literals returned per case by a
+// `switch receiver { case Const: return "text" }` statement in a String() method.
+func switchStringValues(funcDecl *ast.FuncDecl) map[string]string {
+	var receiver string
+	if names := funcDecl.Recv.List[0].Names; len(names) == 1 {
+		receiver = names[0].Name
+	}
+	if receiver == "" || receiver == "_" {
+		return nil
+	}
+
+	values := make(map[string]string)
+	ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+		switchStmt, ok := node.(*ast.SwitchStmt)
+		if !ok {
+			return true
+		}
+		if tag, ok := switchStmt.Tag.(*ast.Ident); !ok || tag.Name != receiver {
+			return true
+		}
+		for _, stmt := range switchStmt.Body.List {
+			clause, ok := stmt.(*ast.CaseClause)
+			if !ok || len(clause.Body) == 0 {
+				continue
+			}
+			returnStmt, ok := clause.Body[len(clause.Body)-1].(*ast.ReturnStmt)
+			if !ok || len(returnStmt.Results) != 1 {
+				continue
+			}
+			lit, ok := returnStmt.Results[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				continue
+			}
+			text, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				continue
+			}
+			for _, expr := range clause.List {
+				if ident, ok := expr.(*ast.Ident); ok {
+					values[ident.Name] = text
+				}
+			}
+		}
+		return false
+	})
+	return values
 }
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/go-openapi/spec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -52,3 +53,148 @@ func TestParseGlobalEnums(t *testing.T) {
 	assert.Equal(t, "SuperSecret", securityLevelEnums[2].key)
 	assert.Equal(t, "This one has a name override and a comment", securityLevelEnums[2].Comment)
 }
+
+func TestParseStringEnums(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+//go:generate stringer -type=Status -linecomment
+type Status int
+
+const (
+	StatusActive   Status = iota // active
+	StatusInactive               // inactive
+	StatusBanned
+)
+
+type Level int
+
+const (
+	LevelLow  Level = 1 // lowest level
+	LevelHigh Level = 2
+	LevelMax  Level = 3
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelLow:
+		return "low"
+	case LevelHigh:
+		return "high"
+	}
+	return "unknown"
+}
+
+type Account struct {
+	Status Status
+	Level  Level
+}
+
+// @Success 200 {object} Account
+// @Router /account [get]
+func Get() {
+}
+`
+	stringer := `// Code generated by "stringer -type=Status -linecomment"; DO NOT EDIT.
+
+package api
+
+import "strconv"
+
+const _Status_name = "activeinactiveStatusBanned"
+
+var _Status_index = [...]uint8{0, 6, 14, 26}
+
+func (i Status) String() string {
+	if i < 0 || i >= Status(len(_Status_index)-1) {
+		return "Status(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Status_name[_Status_index[i]:_Status_index[i+1]]
+}
+`
+	expected := `{
+   "api.Account": {
+      "type": "object",
+      "properties": {
+         "level": {
+            "$ref": "#/definitions/api.Level"
+         },
+         "status": {
+            "$ref": "#/definitions/api.Status"
+         }
+      }
+   },
+   "api.Level": {
+      "type": "string",
+      "enum": [
+         "low",
+         "high"
+      ],
+      "x-enum-comments": {
+         "LevelLow": "lowest level"
+      },
+      "x-enum-descriptions": [
+         "lowest level",
+         ""
+      ],
+      "x-enum-varnames": [
+         "LevelLow",
+         "LevelHigh"
+      ]
+   },
+   "api.Status": {
+      "type": "string",
+      "enum": [
+         "active",
+         "inactive",
+         "StatusBanned"
+      ],
+      "x-enum-comments": {
+         "StatusActive": "active",
+         "StatusInactive": "inactive"
+      },
+      "x-enum-descriptions": [
+         "active",
+         "inactive",
+         ""
+      ],
+      "x-enum-varnames": [
+         "StatusActive",
+         "StatusInactive",
+         "StatusBanned"
+      ]
+   }
+}`
+
+	logger := &testLogger{}
+	p := New(SetStringEnums(true), SetDebugger(logger))
+	err := p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	assert.NoError(t, err)
+	err = p.packages.ParseFile("api", "api/status_string.go", stringer, ParseAll)
+	assert.NoError(t, err)
+
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	out, err := json.MarshalIndent(p.swagger.Definitions, "", "   ")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(out))
+	assert.Contains(t, logger.Messages, "warning: the String() values of LevelMax of api.Level are unknown, they are not in its enum")
+
+	p = New()
+	err = p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	assert.NoError(t, err)
+
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, spec.StringOrArray{INTEGER}, p.swagger.Definitions["api.Level"].Type)
+	assert.Equal(t, []interface{}{1, 2, 3}, p.swagger.Definitions["api.Level"].Enum)
+}
@@ -0,0 +1,174 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	// defaultFailureAttr declares a response of the general API info which every operation lacking its status gets,
+	// e.g. @Failure.default 500 {object} httputil.HTTPError "Internal".
+	defaultFailureAttr = "@failure.default"
+	// noDefaultFailuresAttr opts an operation out of all the default failures, or of the ones of some statuses.
+	noDefaultFailuresAttr = "@nodefaultfailures"
+)
+
+// defaultFailureFilterRegexp matches the filters of a default failure, e.g. tags(accounts,admin) or security(ApiKeyAuth).
+var defaultFailureFilterRegexp = regexp.MustCompile(`\s+(tags|security)\(([^)]*)\)`)
+
+// anySecurity the filter of a default failure applied to the operations with any security requirement, security(*).
+const anySecurity = "*"
+
+// defaultFailure a response declared by @Failure.default.
+type defaultFailure struct {
+	// response the response as @Failure writes it, e.g. 500 {object} httputil.HTTPError "Internal"
+	response string
+	// tags the operations with any of the tags get the response, all of them without tags
+	tags []string
+	// security the operations requiring any of the security schemes, or any scheme for *, get the response
+	security []string
+	// file the file declaring the response, which its type is resolved in
+	file *ast.File
+}
+
+// parseDefaultFailure parses @Failure.default of the general API info.
+func (parser *Parser) parseDefaultFailure(value string) error {
+	failure := defaultFailure{file: parser.generalInfoFile}
+	for _, matches := range defaultFailureFilterRegexp.FindAllStringSubmatch(value, -1) {
+		var names []string
+		for _, name := range strings.Split(matches[2], ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("%s needs a value in %s", matches[1], defaultFailureAttr)
+		}
+
+		switch matches[1] {
+		case "tags":
+			failure.tags = append(failure.tags, names...)
+		case "security":
+			failure.security = append(failure.security, names...)
+		}
+	}
+	failure.response = strings.TrimSpace(defaultFailureFilterRegexp.ReplaceAllString(value, ""))
+
+	fields := FieldsByAnySpace(failure.response, 2)
+	if len(fields) == 0 {
+		return fmt.Errorf("%s needs a status", defaultFailureAttr)
+	}
+	for _, code := range strings.Split(fields[0], ",") {
+		if _, err := strconv.Atoi(code); err != nil && !strings.EqualFold(code, defaultTag) {
+			return fmt.Errorf("invalid status %s of %s", code, defaultFailureAttr)
+		}
+	}
+
+	parser.defaultFailures = append(parser.defaultFailures, failure)
+
+	return nil
+}
+
+// ParseNoDefaultFailuresComment opts the operation out of the default failures, or of the ones of the statuses listed,
+// e.g. @NoDefaultFailures or @NoDefaultFailures 401,403.
+func (operation *Operation) ParseNoDefaultFailuresComment(commentLine string) {
+	if operation.noDefaultFailures == nil {
+		operation.noDefaultFailures = make(map[string]bool)
+	}
+
+	codes := strings.FieldsFunc(commentLine, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(codes) == 0 {
+		operation.noDefaultFailures[""] = true
+	}
+	for _, code := range codes {
+		operation.noDefaultFailures[strings.ToLower(code)] = true
+	}
+}
+
+// applyDefaultFailures adds the default failures to an operation which does not declare their statuses itself,
+// the types of the responses are resolved in the main API file declaring them.
+func (parser *Parser) applyDefaultFailures(operation *Operation, astFile *ast.File) error {
+	if len(parser.defaultFailures) == 0 || operation.noDefaultFailures[""] {
+		return nil
+	}
+
+	for _, failure := range parser.defaultFailures {
+		if !failure.appliesTo(operation, parser.swagger.Security) {
+			continue
+		}
+
+		file := failure.file
+		if file == nil {
+			file = astFile
+		}
+
+		responses := NewOperation(parser)
+		err := responses.ParseResponseComment(failure.response, file)
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", defaultFailureAttr, failure.response, err)
+		}
+
+		if operation.Responses == nil {
+			operation.Responses = &spec.Responses{}
+		}
+		if responses.Responses.Default != nil && operation.Responses.Default == nil && !operation.noDefaultFailures[defaultTag] {
+			operation.Responses.Default = responses.Responses.Default
+		}
+		for code, response := range responses.Responses.StatusCodeResponses {
+			if _, ok := operation.Responses.StatusCodeResponses[code]; ok || operation.noDefaultFailures[strconv.Itoa(code)] {
+				continue
+			}
+			if operation.Responses.StatusCodeResponses == nil {
+				operation.Responses.StatusCodeResponses = make(map[int]spec.Response)
+			}
+			operation.Responses.StatusCodeResponses[code] = response
+		}
+	}
+
+	return nil
+}
+
+// appliesTo whether an operation gets a default failure, by its tags and its security requirements,
+// the global ones for an operation without @Security.
+func (failure defaultFailure) appliesTo(operation *Operation, globalSecurity []map[string][]string) bool {
+	if len(failure.tags) > 0 && !containsAny(operation.Tags, failure.tags) {
+		return false
+	}
+
+	if len(failure.security) == 0 {
+		return true
+	}
+
+	security := operation.Security
+	if security == nil {
+		security = globalSecurity
+	}
+
+	for _, requirement := range security {
+		for scheme := range requirement {
+			if findInSlice(failure.security, anySecurity) || findInSlice(failure.security, scheme) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// containsAny whether values contain any of targets.
+func containsAny(values, targets []string) bool {
+	for _, target := range targets {
+		if findInSlice(values, target) {
+			return true
+		}
+	}
+
+	return false
+}
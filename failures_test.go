@@ -0,0 +1,137 @@
+package swag
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDefaultFailures(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	assert.NoError(t, parseGeneralAPIInfo(p, []string{
+		"@title Accounts API",
+		"@Failure.default 500 {object} api.HTTPError \"Internal\"",
+		"@Failure.default 401 {object} api.HTTPError \"Unauthorized\" security(*)",
+		"@Failure.default 403 {string} string \"Forbidden\" tags(admin) security(OAuth2)",
+	}))
+	assert.Len(t, p.defaultFailures, 3)
+	assert.Equal(t, "401 {object} api.HTTPError \"Unauthorized\"", p.defaultFailures[1].response)
+
+	src := `
+package api
+
+type HTTPError struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+// @Summary List accounts
+// @Success 200 {string} string
+// @Router /accounts [get]
+func ListAccounts() {
+}
+
+// @Summary Show an account
+// @Security ApiKeyAuth
+// @Failure 500 {string} string "own"
+// @Router /accounts/{id} [get]
+func ShowAccount() {
+}
+
+// @Summary Delete an account
+// @Tags admin
+// @Security OAuth2[admin]
+// @NoDefaultFailures 401
+// @Router /accounts/{id} [delete]
+func DeleteAccount() {
+}
+
+// @Summary Health
+// @NoDefaultFailures
+// @Router /health [get]
+func Health() {
+}
+`
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	statuses := func(path, method string) map[int]string {
+		item := p.swagger.Paths.Paths[path]
+		result := make(map[int]string)
+		for code, response := range (*refRouteMethodOp(&item, method)).Responses.StatusCodeResponses {
+			result[code] = response.Description
+		}
+
+		return result
+	}
+
+	assert.Equal(t, map[int]string{200: "OK", 500: "Internal"}, statuses("/accounts", "GET"))
+	assert.Equal(t, map[int]string{401: "Unauthorized", 500: "own"}, statuses("/accounts/{id}", "GET"))
+	assert.Equal(t, map[int]string{403: "Forbidden", 500: "Internal"}, statuses("/accounts/{id}", "DELETE"))
+	assert.Empty(t, statuses("/health", "GET"))
+
+	response := p.swagger.Paths.Paths["/accounts"].Get.Responses.StatusCodeResponses[500]
+	assert.Equal(t, "#/definitions/api.HTTPError", response.Schema.Ref.String())
+}
+
+func TestParseDefaultFailuresError(t *testing.T) {
+	t.Parallel()
+
+	err := parseGeneralAPIInfo(New(), []string{"@Failure.default internal {object} string"})
+	assert.EqualError(t, err, "invalid status internal of @failure.default")
+
+	err = parseGeneralAPIInfo(New(), []string{"@Failure.default 500 {object} string tags()"})
+	assert.EqualError(t, err, "tags needs a value in @failure.default")
+}
+
+func TestParseDefaultFailuresInDeclaringFile(t *testing.T) {
+	t.Parallel()
+
+	httputil := `
+package httputil
+
+type HTTPError struct {
+	Code int ` + "`json:\"code\"`" + `
+}
+`
+	main := `
+package main
+
+import apierr "example.com/svc/httputil"
+
+var _ apierr.HTTPError
+
+// @title Accounts API
+// @Failure.default 500 {object} apierr.HTTPError "Internal"
+func main() {
+}
+`
+	accounts := `
+package accounts
+
+// @Router /accounts [get]
+func List() {
+}
+`
+	mainPath := filepath.Join(t.TempDir(), "main.go")
+	assert.NoError(t, os.WriteFile(mainPath, []byte(main), 0o644))
+
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("example.com/svc/httputil", "httputil/httputil.go", httputil, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("example.com/svc", mainPath, main, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("example.com/svc/accounts", "accounts/accounts.go", accounts, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.ParseGeneralAPIInfo(mainPath))
+
+	// the file of the operation does not import apierr
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+	response := p.swagger.Paths.Paths["/accounts"].Get.Responses.StatusCodeResponses[500]
+	assert.Equal(t, "#/definitions/httputil.HTTPError", response.Schema.Ref.String())
+}
@@ -1,13 +1,17 @@
 package swag
 
 import (
+	"encoding/json"
 	"fmt"
 	"go/ast"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 	"unicode"
 
 	"github.com/go-openapi/spec"
@@ -21,12 +25,60 @@ const (
 	omitEmptyLabel   = "omitempty"
 	swaggerTypeTag   = "swaggertype"
 	swaggerIgnoreTag = "swaggerignore"
+	swaggerEmbedTag  = "swaggerembed"
+	swaggerSchemaTag = "swaggerschema"
+
+	// additionalPropertiesTag allows or forbids the undeclared properties of the inline struct of a field,
+	// or of the inline struct its array items or map values are
+	additionalPropertiesTag = "additionalProperties"
+
+	// schemaFilePrefix refers the swaggerschema tag to a JSON file, relative to the directory of the package
+	schemaFilePrefix = "file:"
+
+	requiredIfExtension = "x-required-if"
+	mapKeyTypeExtension = "x-key-type"
 )
 
+// validatorFormats maps go-playground/validator tags to the format of a string.
+var validatorFormats = map[string]string{
+	"email":        "email",
+	"url":          "uri",
+	"http_url":     "uri",
+	"uri":          "uri",
+	"uuid":         "uuid",
+	"uuid3":        "uuid",
+	"uuid4":        "uuid",
+	"uuid5":        "uuid",
+	"uuid_rfc4122": "uuid",
+	"ip":           "ip",
+	"ipv4":         "ipv4",
+	"ipv6":         "ipv6",
+	"hostname":     "hostname",
+}
+
+// validatorPatterns maps go-playground/validator tags to the pattern of a string.
+var validatorPatterns = map[string]string{
+	"alpha":    `^[a-zA-Z]+$`,
+	"alphanum": `^[a-zA-Z0-9]+$`,
+	"numeric":  `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"e164":     `^\+[1-9]?[0-9]{7,14}$`,
+}
+
+// datetimeFormats maps the layouts of the validator datetime tag to the format of a string.
+var datetimeFormats = map[string]string{
+	time.RFC3339:     "date-time",
+	time.RFC3339Nano: "date-time",
+	time.DateOnly:    "date",
+	time.TimeOnly:    "time",
+}
+
 type tagBaseFieldParser struct {
 	p     *Parser
 	field *ast.Field
 	tag   reflect.StructTag
+
+	// docConflicts the attributes set to different values by the tag and the doc comment of the field
+	docConflicts []string
 }
 
 func newTagBaseFieldParser(p *Parser, field *ast.Field) FieldParser {
@@ -39,16 +91,43 @@ func newTagBaseFieldParser(p *Parser, field *ast.Field) FieldParser {
 		fieldParser.tag = reflect.StructTag(strings.ReplaceAll(field.Tag.Value, "`", ""))
 	}
 
+	fieldParser.mergeDocAnnotations()
+
 	return &fieldParser
 }
 
+// mergeDocAnnotations adds the attributes annotated in the doc comment of the field to its tag,
+// an attribute the tag sets as well keeps the value of the tag.
+func (ps *tagBaseFieldParser) mergeDocAnnotations() {
+	for _, annotation := range parseFieldDocAnnotations(ps.field.Doc, ps.field.Comment) {
+		if value, ok := ps.tag.Lookup(annotation.key); ok {
+			if value != annotation.value {
+				ps.docConflicts = append(ps.docConflicts,
+					fmt.Sprintf("%s is %q in the tag and %q in the doc comment", annotation.key, value, annotation.value))
+			}
+
+			continue
+		}
+
+		tag := annotation.key + ":" + strconv.Quote(annotation.value)
+		if ps.tag != "" {
+			tag = string(ps.tag) + " " + tag
+		}
+		ps.tag = reflect.StructTag(tag)
+	}
+}
+
 func (ps *tagBaseFieldParser) ShouldSkip() bool {
 	// Skip non-exported fields.
 	if ps.field.Names != nil && !ast.IsExported(ps.field.Names[0].Name) {
 		return true
 	}
 
-	if ps.field.Tag == nil {
+	if ps.p.ParseProtobuf && ps.field.Names != nil && strings.HasPrefix(ps.field.Names[0].Name, protobufInternalPrefix) {
+		return true
+	}
+
+	if ps.tag == "" {
 		return false
 	}
 
@@ -63,13 +142,23 @@ func (ps *tagBaseFieldParser) ShouldSkip() bool {
 		return true
 	}
 
-	return false
+	// an invalid version is reported by ComplementSchema
+	since, until, err := ps.fieldVersionRange()
+
+	return err == nil && !ps.p.inAPIVersion(since, until)
 }
 
 func (ps *tagBaseFieldParser) FieldNames() ([]string, error) {
 	if len(ps.field.Names) <= 1 {
+		if ps.p.ParseProtobuf && ps.tag != "" {
+			// the name protojson uses
+			if name := protobufJSONName(ps.tag.Get(protobufTag)); name != "" {
+				return []string{name}, nil
+			}
+		}
+
 		// if embedded but with a json/form name ??
-		if ps.field.Tag != nil {
+		if ps.tag != "" {
 			// json:"tag,hoge"
 			name := strings.TrimSpace(strings.Split(ps.tag.Get(jsonTag), ",")[0])
 			if name != "" {
@@ -101,7 +190,7 @@ func (ps *tagBaseFieldParser) FieldNames() ([]string, error) {
 }
 
 func (ps *tagBaseFieldParser) FirstTagValue(tag string) string {
-	if ps.field.Tag != nil {
+	if ps.tag != "" {
 		return strings.TrimRight(strings.TrimSpace(strings.Split(ps.tag.Get(tag), ",")[0]), "[]")
 	}
 	return ""
@@ -160,7 +249,7 @@ func toLowerCamelCase(in string) string {
 }
 
 func (ps *tagBaseFieldParser) CustomSchema() (*spec.Schema, error) {
-	if ps.field.Tag == nil {
+	if ps.tag == "" {
 		return nil, nil
 	}
 
@@ -169,7 +258,170 @@ func (ps *tagBaseFieldParser) CustomSchema() (*spec.Schema, error) {
 		return BuildCustomSchema(strings.Split(typeTag, ","))
 	}
 
-	return nil, nil
+	schemaTag := strings.TrimSpace(ps.tag.Get(swaggerSchemaTag))
+	if schemaTag == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(schemaTag, schemaFilePrefix) {
+		return BuildRawSchema([]byte(schemaTag))
+	}
+
+	info := ps.p.packages.findFileOfNode(ps.field)
+	if info == nil {
+		return nil, fmt.Errorf("cannot find the package of schema file %s", schemaTag)
+	}
+
+	schemaFile := filepath.Join(filepath.Dir(info.Path), strings.TrimPrefix(schemaTag, schemaFilePrefix))
+	data, err := os.ReadFile(schemaFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file: %w", err)
+	}
+
+	schema, err := BuildRawSchema(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", schemaFile, err)
+	}
+
+	return schema, nil
+}
+
+// fieldDocAttributes the tags which the doc comment of a field may set with an annotation, e.g. // @pattern ^[a-z]+$,
+// by their lower case names.
+var fieldDocAttributes = func() map[string]string {
+	attributes := make(map[string]string)
+	for _, tag := range []string{
+		exampleTag, defaultTag, enumsTag, formatTag, titleTag, patternTag, validateTag, bindingTag,
+		minimumTag, maximumTag, minLengthTag, maxLengthTag, multipleOfTag, readOnlyTag, extensionsTag,
+		swaggerTypeTag, swaggerIgnoreTag, swaggerSchemaTag, additionalPropertiesTag, enumVarNamesExtension,
+		sinceTag, untilTag,
+	} {
+		attributes[strings.ToLower(tag)] = tag
+	}
+
+	return attributes
+}()
+
+var fieldDocAnnotationRegexp = regexp.MustCompile(`^@([\w-]+)(?:\s+(.*))?$`)
+
+// fieldDocAnnotation an attribute set by the doc comment of a field.
+type fieldDocAnnotation struct {
+	key   string
+	value string
+}
+
+// parseFieldDocAnnotations parses the attributes annotated in the comments of a field, such as
+//
+//	// @pattern ^[a-z]+$
+//	// @example {
+//	//   "a": 1
+//	// }
+//
+// a JSON value continues over the next lines until its brackets are balanced.
+func parseFieldDocAnnotations(commentGroups ...*ast.CommentGroup) []fieldDocAnnotation {
+	var annotations []fieldDocAnnotation
+	for _, commentGroup := range commentGroups {
+		if commentGroup == nil {
+			continue
+		}
+
+		lines := strings.Split(commentGroup.Text(), "\n")
+		for i := 0; i < len(lines); i++ {
+			matches := fieldDocAnnotationRegexp.FindStringSubmatch(strings.TrimSpace(lines[i]))
+			if len(matches) != 3 {
+				continue
+			}
+
+			key, ok := fieldDocAttributes[strings.ToLower(matches[1])]
+			if !ok {
+				continue
+			}
+
+			value := strings.TrimSpace(matches[2])
+			for depth := jsonDepth(value); depth > 0 && i+1 < len(lines); depth = jsonDepth(value) {
+				i++
+				value += "\n" + lines[i]
+			}
+
+			annotations = append(annotations, fieldDocAnnotation{key: key, value: value})
+		}
+	}
+
+	return annotations
+}
+
+// fieldDocDescription returns the text of a field comment without the lines of its annotations.
+func fieldDocDescription(commentGroup *ast.CommentGroup) string {
+	lines := strings.Split(commentGroup.Text(), "\n")
+
+	description := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		matches := fieldDocAnnotationRegexp.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		if len(matches) != 3 {
+			description = append(description, lines[i])
+
+			continue
+		}
+
+		if _, ok := fieldDocAttributes[strings.ToLower(matches[1])]; !ok {
+			description = append(description, lines[i])
+
+			continue
+		}
+
+		for value := matches[2]; jsonDepth(value) > 0 && i+1 < len(lines); {
+			i++
+			value += lines[i]
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(description, "\n"))
+}
+
+// jsonDepth returns how many brackets of a JSON value are left open, ignoring those in strings.
+func jsonDepth(value string) int {
+	if !strings.HasPrefix(value, "{") && !strings.HasPrefix(value, "[") {
+		return 0
+	}
+
+	var depth int
+	var inString, escaped bool
+	for _, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && r == '\\':
+			escaped = true
+		case r == '"':
+			inString = !inString
+		case inString:
+		case r == '{' || r == '[':
+			depth++
+		case r == '}' || r == ']':
+			depth--
+		}
+	}
+
+	return depth
+}
+
+// parseJSONValue parses the JSON value of an example or a default of an array or an object.
+func parseJSONValue(schemaType, value string) (interface{}, bool) {
+	if schemaType != ARRAY && schemaType != OBJECT {
+		return nil, false
+	}
+
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "{") && !strings.HasPrefix(value, "[") {
+		return nil, false
+	}
+
+	var result interface{}
+	if err := json.Unmarshal([]byte(value), &result); err != nil {
+		return nil, false
+	}
+
+	return result, true
 }
 
 type structField struct {
@@ -188,6 +440,14 @@ type structField struct {
 	enums        []interface{}
 	enumVarNames []interface{}
 	unique       bool
+
+	exclusiveMaximum bool
+	exclusiveMinimum bool
+	patterns         []string
+	requiredIf       []interface{}
+
+	// diveRules the validator rules following dive, they apply to the elements of an array or map
+	diveRules []string
 }
 
 // splitNotWrapped slices s into all substrings separated by sep if sep is not
@@ -242,11 +502,32 @@ func splitNotWrapped(s string, sep rune) []string {
 
 // ComplementSchema complement schema with field properties
 func (ps *tagBaseFieldParser) ComplementSchema(schema *spec.Schema) error {
+	if ps.tag.Get(swaggerSchemaTag) != "" {
+		// a raw schema is used as is, only its description falls back on the field
+		if schema.Description == "" {
+			schema.Description = ps.description()
+		}
+
+		return nil
+	}
+
 	types := ps.p.GetSchemaTypePath(schema, 2)
 	if len(types) == 0 {
 		return fmt.Errorf("invalid type for field: %s", ps.field.Names[0])
 	}
 
+	if _, _, err := ps.fieldVersionRange(); err != nil {
+		return err
+	}
+
+	for _, conflict := range ps.docConflicts {
+		if ps.p.Strict {
+			return fmt.Errorf("conflicting annotation: %s", conflict)
+		}
+
+		ps.p.debug.Printf("warning: conflicting annotation: %s, using the tag", conflict)
+	}
+
 	if IsRefSchema(schema) {
 		var newSchema = spec.Schema{}
 		err := ps.complementSchema(&newSchema, types)
@@ -262,16 +543,54 @@ func (ps *tagBaseFieldParser) ComplementSchema(schema *spec.Schema) error {
 	return ps.complementSchema(schema, types)
 }
 
-// complementSchema complement schema with field properties
-func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []string) error {
-	if ps.field.Tag == nil {
-		if ps.field.Doc != nil {
-			schema.Description = strings.TrimSpace(ps.field.Doc.Text())
-		}
+// setInlineAdditionalProperties allows or forbids the undeclared properties of the inline struct of a field,
+// e.g. `additionalProperties:"true"` on a struct{...}, []struct{...} or map[string]struct{...} field.
+func setInlineAdditionalProperties(schema *spec.Schema, value string) error {
+	allows, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s tag %q, expected true or false", additionalPropertiesTag, value)
+	}
+
+	target := schema
+	switch {
+	case schema.Items != nil && schema.Items.Schema != nil:
+		target = schema.Items.Schema
+	case schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil:
+		target = schema.AdditionalProperties.Schema
+	}
+
+	if !target.Type.Contains(OBJECT) || target.AdditionalProperties != nil && target.AdditionalProperties.Schema != nil {
+		return fmt.Errorf("%s tag applies to inline structs only", additionalPropertiesTag)
+	}
+
+	target.AdditionalProperties = &spec.SchemaOrBool{Allows: allows}
+
+	return nil
+}
+
+// description returns the description tag of the field, or else its doc or line comment.
+func (ps *tagBaseFieldParser) description() string {
+	if description := ps.tag.Get(descriptionTag); description != "" {
+		return description
+	}
 
-		if schema.Description == "" && ps.field.Comment != nil {
-			schema.Description = strings.TrimSpace(ps.field.Comment.Text())
+	if ps.field.Doc != nil {
+		if description := fieldDocDescription(ps.field.Doc); description != "" {
+			return description
 		}
+	}
+
+	if ps.field.Comment != nil {
+		return fieldDocDescription(ps.field.Comment)
+	}
+
+	return ""
+}
+
+// complementSchema complement schema with field properties
+func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []string) error {
+	if ps.tag == "" {
+		schema.Description = ps.description()
 
 		return nil
 	}
@@ -298,6 +617,11 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 		parseValidTags(validateTagValue, field)
 	}
 
+	patternTagValue := ps.tag.Get(patternTag)
+	if patternTagValue != "" {
+		field.patterns = append(field.patterns, patternTagValue)
+	}
+
 	enumsTagValue := ps.tag.Get(enumsTag)
 	if enumsTagValue != "" {
 		err := parseEnumTags(enumsTagValue, field)
@@ -357,7 +681,8 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 
 	// json:"name,string" or json:",string"
 	exampleTagValue, ok := ps.tag.Lookup(exampleTag)
-	if ok {
+	// an example referring to a constant or a variable is evaluated by the parser, which knows the file of the field
+	if ok && !isValueReference(exampleTagValue) {
 		field.exampleValue = exampleTagValue
 
 		if !strings.Contains(jsonTagValue, ",string") {
@@ -395,31 +720,36 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 		}
 	}
 
-	// Check for description tag first, then fallback to comments
-	descriptionTagValue := ps.tag.Get(descriptionTag)
-	if descriptionTagValue != "" {
-		schema.Description = descriptionTagValue
-	} else if ps.field.Doc != nil {
-		schema.Description = strings.TrimSpace(ps.field.Doc.Text())
-	} else if ps.field.Comment != nil {
-		schema.Description = strings.TrimSpace(ps.field.Comment.Text())
-	}
+	schema.Description = ps.description()
 
 	schema.ReadOnly = ps.tag.Get(readOnlyTag) == "true"
 
-	defaultTagValue, ok := ps.tag.Lookup(defaultTag)
+	additionalPropertiesTagValue, ok := ps.tag.Lookup(additionalPropertiesTag)
 	if ok {
-		value, err := defineType(field.schemaType, defaultTagValue)
+		err := setInlineAdditionalProperties(schema, additionalPropertiesTagValue)
 		if err != nil {
 			return err
 		}
+	}
+
+	defaultTagValue, ok := ps.tag.Lookup(defaultTag)
+	if ok && !isValueReference(defaultTagValue) {
+		value, isJSON := parseJSONValue(field.schemaType, defaultTagValue)
+		if !isJSON {
+			var err error
+			value, err = defineType(field.schemaType, defaultTagValue)
+			if err != nil {
+				return err
+			}
+		}
 
 		schema.Default = value
 	}
 
 	schema.Example = field.exampleValue
 
-	if field.schemaType != ARRAY {
+	// the binary format of an uploaded file is a part of its type
+	if field.schemaType != ARRAY && (field.formatType != "" || !IsBinarySchema(schema)) {
 		schema.Format = field.formatType
 	}
 	schema.Title = field.title
@@ -466,19 +796,138 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 		schema.UniqueItems = field.unique
 
 		eleSchema = schema.Items.Schema
-		eleSchema.Format = field.formatType
+		if field.formatType != "" || !IsBinarySchema(eleSchema) {
+			eleSchema.Format = field.formatType
+		}
 	}
 
 	eleSchema.Maximum = field.maximum
 	eleSchema.Minimum = field.minimum
+	eleSchema.ExclusiveMaximum = field.exclusiveMaximum
+	eleSchema.ExclusiveMinimum = field.exclusiveMinimum
 	eleSchema.MultipleOf = field.multipleOf
 	eleSchema.MaxLength = field.maxLength
 	eleSchema.MinLength = field.minLength
+	eleSchema.Pattern = field.pattern()
 	eleSchema.Enum = field.enums
 
+	if len(field.requiredIf) > 0 {
+		schema.AddExtension(requiredIfExtension, field.requiredIf)
+	}
+
+	if len(field.diveRules) > 0 {
+		ps.complementElementSchema(schema, field.diveRules)
+	}
+
 	return nil
 }
 
+// complementElementSchema applies the validator rules following a dive to the items of an array,
+// or to the keys (between keys and endkeys) and values of a map.
+func (ps *tagBaseFieldParser) complementElementSchema(schema *spec.Schema, rules []string) {
+	if len(rules) > 0 && rules[0] == "keys" {
+		keyRules := rules[1:]
+		rules = nil
+		for i, rule := range keyRules {
+			if rule == "endkeys" {
+				keyRules, rules = keyRules[:i], keyRules[i+1:]
+				break
+			}
+		}
+
+		if schema.AdditionalProperties != nil && len(keyRules) > 0 {
+			keySchema := PrimitiveSchema(STRING)
+			if ext, ok := schema.Extensions[mapKeyTypeExtension].(*spec.Schema); ok {
+				copied := *ext
+				keySchema = &copied
+			}
+			ps.complementValidatedSchema(keySchema, keyRules)
+
+			// copy the extensions, they may be shared with the schema of a named type
+			extensions := make(spec.Extensions, len(schema.Extensions)+1)
+			for k, v := range schema.Extensions {
+				extensions[k] = v
+			}
+			extensions.Add(mapKeyTypeExtension, keySchema)
+			schema.Extensions = extensions
+		}
+	}
+
+	if len(rules) == 0 {
+		return
+	}
+
+	switch {
+	case schema.Items != nil && schema.Items.Schema != nil:
+		// copy the items, they may be shared with the schema of a named type
+		items := *schema.Items.Schema
+		ps.complementValidatedSchema(&items, rules)
+		schema.Items = &spec.SchemaOrArray{Schema: &items}
+	case schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil:
+		values := *schema.AdditionalProperties.Schema
+		ps.complementValidatedSchema(&values, rules)
+		schema.AdditionalProperties = &spec.SchemaOrBool{Allows: true, Schema: &values}
+	}
+}
+
+// complementValidatedSchema applies validator rules to the schema of an array item, map key or map value.
+func (ps *tagBaseFieldParser) complementValidatedSchema(schema *spec.Schema, rules []string) {
+	target := schema
+	if IsRefSchema(schema) {
+		target = &spec.Schema{}
+	}
+
+	types := ps.p.GetSchemaTypePath(schema, 2)
+	if len(types) == 0 {
+		return
+	}
+
+	field := &structField{schemaType: types[0]}
+	if len(types) > 1 {
+		field.arrayType = types[1]
+	}
+	field.parseValidRules(rules)
+
+	if field.maximum != nil {
+		target.Maximum, target.ExclusiveMaximum = field.maximum, field.exclusiveMaximum
+	}
+	if field.minimum != nil {
+		target.Minimum, target.ExclusiveMinimum = field.minimum, field.exclusiveMinimum
+	}
+	if field.maxLength != nil {
+		target.MaxLength = field.maxLength
+	}
+	if field.minLength != nil {
+		target.MinLength = field.minLength
+	}
+	if field.maxItems != nil {
+		target.MaxItems = field.maxItems
+	}
+	if field.minItems != nil {
+		target.MinItems = field.minItems
+	}
+	if field.unique {
+		target.UniqueItems = true
+	}
+	if pattern := field.pattern(); pattern != "" {
+		target.Pattern = pattern
+	}
+	if field.formatType != "" && target.Format == "" {
+		target.Format = field.formatType
+	}
+	if len(field.enums) > 0 {
+		target.Enum = field.enums
+	}
+
+	if target != schema && !reflect.ValueOf(*target).IsZero() {
+		*schema = *(target.WithAllOf(*schema))
+	}
+
+	if len(field.diveRules) > 0 {
+		ps.complementElementSchema(schema, field.diveRules)
+	}
+}
+
 func getFloatTag(structTag reflect.StructTag, tagName string) (*float64, error) {
 	strValue := structTag.Get(tagName)
 	if strValue == "" {
@@ -508,7 +957,7 @@ func getIntTag(structTag reflect.StructTag, tagName string) (*int64, error) {
 }
 
 func (ps *tagBaseFieldParser) IsRequired() (bool, error) {
-	if ps.field.Tag == nil {
+	if ps.tag == "" {
 		return false, nil
 	}
 
@@ -551,7 +1000,11 @@ func (ps *tagBaseFieldParser) IsRequired() (bool, error) {
 func parseValidTags(validTag string, sf *structField) {
 	// `validate:"required,max=10,min=1"`
 	// ps. required checked by IsRequired().
-	for _, val := range strings.Split(validTag, ",") {
+	sf.parseValidRules(strings.Split(validTag, ","))
+}
+
+func (sf *structField) parseValidRules(rules []string) {
+	for i, val := range rules {
 		var (
 			valValue string
 			keyVal   = strings.Split(val, "=")
@@ -570,6 +1023,26 @@ func parseValidTags(validTag string, sf *structField) {
 			sf.setMax(valValue)
 		case "min", "gte":
 			sf.setMin(valValue)
+		case "lt":
+			sf.setExclusiveMax(valValue)
+		case "gt":
+			sf.setExclusiveMin(valValue)
+		case "len":
+			sf.setMin(valValue)
+			sf.setMax(valValue)
+		case "datetime":
+			sf.setFormat(datetimeFormats[valValue])
+		case "startswith":
+			sf.patterns = append(sf.patterns, "^"+regexp.QuoteMeta(valValue))
+		case "endswith":
+			sf.patterns = append(sf.patterns, regexp.QuoteMeta(valValue)+"$")
+		case "contains":
+			sf.patterns = append(sf.patterns, regexp.QuoteMeta(valValue))
+		case "required_if", "required_unless", "required_with", "required_with_all", "required_without", "required_without_all":
+			sf.requiredIf = append(sf.requiredIf, map[string]interface{}{
+				"rule":  keyVal[0],
+				"param": valValue,
+			})
 		case "oneof":
 			sf.setOneOf(valValue)
 		case "unique":
@@ -577,10 +1050,15 @@ func parseValidTags(validTag string, sf *structField) {
 				sf.unique = true
 			}
 		case "dive":
-			// ignore dive
+			// the following rules apply to the elements
+			sf.diveRules = rules[i+1:]
 			return
 		default:
-			continue
+			if format, ok := validatorFormats[keyVal[0]]; ok {
+				sf.setFormat(format)
+			} else if pattern, ok := validatorPatterns[keyVal[0]]; ok {
+				sf.patterns = append(sf.patterns, pattern)
+			}
 		}
 	}
 }
@@ -652,6 +1130,69 @@ func (sf *structField) setMin(valValue string) {
 	}
 }
 
+func (sf *structField) setExclusiveMin(valValue string) {
+	value, err := strconv.ParseFloat(valValue, 64)
+	if err != nil {
+		return
+	}
+
+	switch sf.schemaType {
+	case INTEGER, NUMBER:
+		sf.minimum = &value
+		sf.exclusiveMinimum = true
+	case STRING:
+		intValue := int64(value) + 1
+		sf.minLength = &intValue
+	case ARRAY:
+		intValue := int64(value) + 1
+		sf.minItems = &intValue
+	}
+}
+
+func (sf *structField) setExclusiveMax(valValue string) {
+	value, err := strconv.ParseFloat(valValue, 64)
+	if err != nil {
+		return
+	}
+
+	switch sf.schemaType {
+	case INTEGER, NUMBER:
+		sf.maximum = &value
+		sf.exclusiveMaximum = true
+	case STRING:
+		intValue := int64(value) - 1
+		sf.maxLength = &intValue
+	case ARRAY:
+		intValue := int64(value) - 1
+		sf.maxItems = &intValue
+	}
+}
+
+// setFormat sets the format implied by a validator tag, unless it is set by the format tag.
+func (sf *structField) setFormat(format string) {
+	if format != "" && sf.formatType == "" {
+		sf.formatType = format
+	}
+}
+
+// pattern returns the patterns collected from validator tags, combined by lookaheads if there are several.
+func (sf *structField) pattern() string {
+	if len(sf.patterns) <= 1 {
+		return strings.Join(sf.patterns, "")
+	}
+
+	var pattern strings.Builder
+	for _, p := range sf.patterns {
+		pattern.WriteString("(?=")
+		if !strings.HasPrefix(p, "^") {
+			pattern.WriteString("^.*")
+		}
+		pattern.WriteString(p)
+		pattern.WriteString(")")
+	}
+	return pattern.String()
+}
+
 func (sf *structField) setMax(valValue string) {
 	value, err := strconv.ParseFloat(valValue, 64)
 	if err != nil {
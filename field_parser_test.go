@@ -1,7 +1,10 @@
 package swag
 
 import (
+	"encoding/json"
 	"go/ast"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/go-openapi/spec"
@@ -637,6 +640,194 @@ func TestValidTags(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, []interface{}{"aa", "bb"}, schema.Enum)
 	})
+	t.Run("Validator exclusive bounds and length tags", func(t *testing.T) {
+		t.Parallel()
+
+		schema := spec.Schema{}
+		schema.Type = []string{"integer"}
+		err := newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{
+				Value: `json:"test" validate:"gt=0,lt=100"`,
+			}},
+		).ComplementSchema(&schema)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(0), *schema.Minimum)
+		assert.True(t, schema.ExclusiveMinimum)
+		assert.Equal(t, float64(100), *schema.Maximum)
+		assert.True(t, schema.ExclusiveMaximum)
+
+		schema = spec.Schema{}
+		schema.Type = []string{"string"}
+		err = newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{
+				Value: `json:"test" binding:"gt=2,lt=10"`,
+			}},
+		).ComplementSchema(&schema)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), *schema.MinLength)
+		assert.Equal(t, int64(9), *schema.MaxLength)
+
+		schema = spec.Schema{}
+		schema.Type = []string{"string"}
+		err = newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{
+				Value: `json:"test" validate:"len=3"`,
+			}},
+		).ComplementSchema(&schema)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), *schema.MinLength)
+		assert.Equal(t, int64(3), *schema.MaxLength)
+
+		schema = spec.Schema{}
+		schema.Type = []string{"array"}
+		schema.Items = &spec.SchemaOrArray{
+			Schema: &spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: []string{"string"},
+				},
+			},
+		}
+		err = newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{
+				Value: `json:"test" validate:"len=2"`,
+			}},
+		).ComplementSchema(&schema)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), *schema.MinItems)
+		assert.Equal(t, int64(2), *schema.MaxItems)
+	})
+
+	t.Run("Validator format and pattern tags", func(t *testing.T) {
+		t.Parallel()
+
+		for tag, format := range map[string]string{
+			`validate:"required,email"`:                     "email",
+			`binding:"url"`:                                 "uri",
+			`validate:"uuid4"`:                              "uuid",
+			`validate:"ip"`:                                 "ip",
+			`validate:"datetime=2006-01-02"`:                "date",
+			`validate:"datetime=2006-01-02T15:04:05Z07:00"`: "date-time",
+			`validate:"email" format:"idn-email"`:           "idn-email",
+		} {
+			schema := spec.Schema{}
+			schema.Type = []string{"string"}
+			err := newTagBaseFieldParser(
+				&Parser{},
+				&ast.Field{Tag: &ast.BasicLit{
+					Value: `json:"test" ` + tag,
+				}},
+			).ComplementSchema(&schema)
+			assert.NoError(t, err)
+			assert.Equal(t, format, schema.Format, tag)
+		}
+
+		for tag, pattern := range map[string]string{
+			`validate:"alphanum"`:                     `^[a-zA-Z0-9]+$`,
+			`binding:"e164"`:                          `^\+[1-9]?[0-9]{7,14}$`,
+			`validate:"startswith=a.b"`:               `^a\.b`,
+			`validate:"startswith=ab,endswith=yz"`:    `(?=^ab)(?=^.*yz$)`,
+			`validate:"required,contains=x,alphanum"`: `(?=^.*x)(?=^[a-zA-Z0-9]+$)`,
+		} {
+			schema := spec.Schema{}
+			schema.Type = []string{"string"}
+			err := newTagBaseFieldParser(
+				&Parser{},
+				&ast.Field{Tag: &ast.BasicLit{
+					Value: `json:"test" ` + tag,
+				}},
+			).ComplementSchema(&schema)
+			assert.NoError(t, err)
+			assert.Equal(t, pattern, schema.Pattern, tag)
+		}
+	})
+
+	t.Run("Validator conditional required tags", func(t *testing.T) {
+		t.Parallel()
+
+		schema := spec.Schema{}
+		schema.Type = []string{"string"}
+		err := newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{
+				Value: `json:"test" validate:"required_without=Email,required_if=Kind company"`,
+			}},
+		).ComplementSchema(&schema)
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"rule": "required_without", "param": "Email"},
+			map[string]interface{}{"rule": "required_if", "param": "Kind company"},
+		}, schema.Extensions["x-required-if"])
+
+		required, err := newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{
+				Value: `json:"test" validate:"required_without=Email"`,
+			}},
+		).IsRequired()
+		assert.NoError(t, err)
+		assert.False(t, required)
+	})
+
+	t.Run("Dive tag", func(t *testing.T) {
+		t.Parallel()
+
+		schema := spec.ArrayProperty(spec.StringProperty())
+		err := newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{
+				Value: `json:"test" validate:"min=1,dive,min=3,max=20,alphanum"`,
+			}},
+		).ComplementSchema(schema)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), *schema.MinItems)
+		assert.Equal(t, int64(3), *schema.Items.Schema.MinLength)
+		assert.Equal(t, int64(20), *schema.Items.Schema.MaxLength)
+		assert.Equal(t, `^[a-zA-Z0-9]+$`, schema.Items.Schema.Pattern)
+
+		schema = spec.ArrayProperty(spec.ArrayProperty(spec.Int64Property()))
+		err = newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{
+				Value: `json:"test" binding:"dive,len=2,dive,gt=0"`,
+			}},
+		).ComplementSchema(schema)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), *schema.Items.Schema.MinItems)
+		assert.Equal(t, int64(2), *schema.Items.Schema.MaxItems)
+		assert.Equal(t, float64(0), *schema.Items.Schema.Items.Schema.Minimum)
+		assert.True(t, schema.Items.Schema.Items.Schema.ExclusiveMinimum)
+
+		schema = spec.MapProperty(spec.StringProperty())
+		err = newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{
+				Value: `json:"test" validate:"required,dive,keys,min=2,max=8,endkeys,oneof=red green"`,
+			}},
+		).ComplementSchema(schema)
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{"red", "green"}, schema.AdditionalProperties.Schema.Enum)
+		keySchema := schema.Extensions["x-key-type"].(*spec.Schema)
+		assert.Equal(t, spec.StringOrArray{"string"}, keySchema.Type)
+		assert.Equal(t, int64(2), *keySchema.MinLength)
+		assert.Equal(t, int64(8), *keySchema.MaxLength)
+
+		items := spec.StringProperty()
+		schema = spec.ArrayProperty(items)
+		err = newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{
+				Value: `json:"test" validate:"dive,email"`,
+			}},
+		).ComplementSchema(schema)
+		assert.NoError(t, err)
+		assert.Equal(t, "email", schema.Items.Schema.Format)
+		assert.Empty(t, items.Format)
+	})
+
 	t.Run("Required with unique tag", func(t *testing.T) {
 		t.Parallel()
 
@@ -779,3 +970,246 @@ func TestValidTags(t *testing.T) {
 		assert.Equal(t, "y", fieldnames[1])
 	})
 }
+
+func TestFieldDocAnnotations(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Settings struct {
+	// Slug of the settings
+	// @pattern ^[a-z]+(-[a-z]+)*$
+	// @maxLength 32
+	Slug string ` + "`json:\"slug\" validate:\"required\"`" + `
+
+	// Limits per resource
+	// @example {
+	//   "cpu": 2,
+	//   "memory": 512
+	// }
+	// @default {"cpu": 1}
+	Limits map[string]int ` + "`json:\"limits\"`" + `
+
+	Tags []string // @example ["a", "b"]
+
+	// contact @admin for help
+	Owner string
+}
+
+// @Success 200 {object} Settings
+// @Router /settings [get]
+func Get() {
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	expected := `{
+   "type": "object",
+   "required": [
+      "slug"
+   ],
+   "properties": {
+      "limits": {
+         "description": "Limits per resource",
+         "type": "object",
+         "default": {
+            "cpu": 1
+         },
+         "additionalProperties": {
+            "type": "integer"
+         },
+         "example": {
+            "cpu": 2,
+            "memory": 512
+         }
+      },
+      "owner": {
+         "description": "contact @admin for help",
+         "type": "string"
+      },
+      "slug": {
+         "description": "Slug of the settings",
+         "type": "string",
+         "maxLength": 32,
+         "pattern": "^[a-z]+(-[a-z]+)*$"
+      },
+      "tags": {
+         "type": "array",
+         "items": {
+            "type": "string"
+         },
+         "example": [
+            "a",
+            "b"
+         ]
+      }
+   }
+}`
+	b, _ := json.MarshalIndent(p.swagger.Definitions["api.Settings"], "", "   ")
+	assert.Equal(t, expected, string(b))
+}
+
+func TestFieldDocAnnotationConflicts(t *testing.T) {
+	t.Parallel()
+
+	field := &ast.Field{
+		Names: []*ast.Ident{{Name: "Name"}},
+		Tag:   &ast.BasicLit{Value: "`json:\"name\" maxLength:\"10\"`"},
+		Doc: &ast.CommentGroup{List: []*ast.Comment{
+			{Text: "// @maxLength 20"},
+			{Text: "// @minLength 2"},
+		}},
+	}
+
+	schema := spec.StringProperty()
+	err := newTagBaseFieldParser(New(), field).ComplementSchema(schema)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), *schema.MaxLength)
+	assert.Equal(t, int64(2), *schema.MinLength)
+
+	err = newTagBaseFieldParser(New(SetStrict(true)), field).ComplementSchema(spec.StringProperty())
+	assert.EqualError(t, err, `conflicting annotation: maxLength is "10" in the tag and "20" in the doc comment`)
+}
+
+func TestSwaggerSchemaTag(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	src := `
+package api
+
+type Payment struct {
+	// Amount in cents or as a decimal string
+	Amount interface{} ` + "`json:\"amount\" swaggerschema:\"{\\\"oneOf\\\":[{\\\"type\\\":\\\"integer\\\"},{\\\"type\\\":\\\"string\\\",\\\"pattern\\\":\\\"^[0-9]+\\\\\\\\.[0-9]{2}$\\\"}]}\" validate:\"required\"`" + `
+
+	// @swaggerschema {
+	//   "type": "array",
+	//   "items": {"type": "string", "format": "uuid"},
+	//   "maxItems": 10
+	// }
+	Refs []string ` + "`json:\"refs\"`" + `
+
+	Money map[string]interface{} ` + "`json:\"money\" swaggerschema:\"file:money.json\"`" + `
+}
+
+// @Success 200 {object} Payment
+// @Router /payments [get]
+func Get() {
+}
+`
+	money := `{"description": "an amount of money", "type": "object", "properties": {"currency": {"type": "string"}}, "required": ["currency"]}`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "api.go"), []byte(src), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "money.json"), []byte(money), 0o644))
+
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", filepath.Join(dir, "api.go"), nil, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	expected := `{
+   "type": "object",
+   "required": [
+      "amount"
+   ],
+   "properties": {
+      "amount": {
+         "description": "Amount in cents or as a decimal string",
+         "oneOf": [
+            {
+               "type": "integer"
+            },
+            {
+               "type": "string",
+               "pattern": "^[0-9]+\\.[0-9]{2}$"
+            }
+         ]
+      },
+      "money": {
+         "description": "an amount of money",
+         "type": "object",
+         "required": [
+            "currency"
+         ],
+         "properties": {
+            "currency": {
+               "type": "string"
+            }
+         }
+      },
+      "refs": {
+         "type": "array",
+         "maxItems": 10,
+         "items": {
+            "type": "string",
+            "format": "uuid"
+         }
+      }
+   }
+}`
+	b, _ := json.MarshalIndent(p.swagger.Definitions["api.Payment"], "", "   ")
+	assert.Equal(t, expected, string(b))
+}
+
+func TestBuildRawSchema(t *testing.T) {
+	t.Parallel()
+
+	for fragment, expected := range map[string]string{
+		`{"type": "strng"}`: "invalid JSON schema: #: unknown type strng",
+		`{"type": "array"}`: "invalid JSON schema: #: missing items of array",
+		`{"oneOf": [{"type": "string", "patern": "x"}]}`:                 "invalid JSON schema: #/oneOf/0: unknown keyword patern",
+		`{"type": "object", "properties": {"a": {}}, "required": ["b"]}`: "invalid JSON schema: #: required property b is not declared",
+		`{"type": `: "invalid JSON schema: unexpected end of JSON input",
+	} {
+		_, err := BuildRawSchema([]byte(fragment))
+		assert.EqualError(t, err, expected, fragment)
+	}
+
+	schema, err := BuildRawSchema([]byte(`{"type": "string", "nullable": true, "x-go-type": "Money"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, spec.StringOrArray{STRING}, schema.Type)
+
+	schema, err = BuildRawSchema([]byte(`{"type": "string", "pattern": "^(?!.*\\.\\.)[a-z.]+$"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `^(?!.*\.\.)[a-z.]+$`, schema.Pattern)
+
+	schema, err = BuildRawSchema([]byte(`{
+		"oneOf": [{"$ref": "#/definitions/Cat"}, {"$ref": "#/definitions/Dog"}],
+		"discriminator": {"propertyName": "kind", "mapping": {"cat": "#/definitions/Cat"}}
+	}`))
+	assert.NoError(t, err)
+	assert.Len(t, schema.OneOf, 2)
+	assert.Equal(t, map[string]interface{}{
+		"propertyName": "kind",
+		"mapping":      map[string]interface{}{"cat": "#/definitions/Cat"},
+	}, schema.ExtraProps["discriminator"])
+
+	schema, err = BuildRawSchema([]byte(`{"type": "object", "properties": {
+		"price": {"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 100},
+		"kind": {"const": "fee", "examples": ["fee"]}
+	}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), schema.Properties["price"].ExtraProps["exclusiveMinimum"])
+	assert.Equal(t, float64(100), schema.Properties["price"].ExtraProps["exclusiveMaximum"])
+	assert.False(t, schema.Properties["price"].ExclusiveMinimum)
+	assert.Equal(t, "fee", schema.Properties["kind"].ExtraProps["const"])
+	assert.Equal(t, []interface{}{"fee"}, schema.Properties["kind"].ExtraProps["examples"])
+
+	b, err := json.Marshal(schema.Properties["price"])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 100}`, string(b))
+
+	schema, err = BuildRawSchema([]byte(`{"type": "number", "minimum": 0, "exclusiveMinimum": true}`))
+	assert.NoError(t, err)
+	assert.True(t, schema.ExclusiveMinimum)
+	assert.Empty(t, schema.ExtraProps)
+}
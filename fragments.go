@@ -0,0 +1,103 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+)
+
+// fragmentAttributes the annotations a fragment consists of.
+var fragmentAttributes = map[string]bool{
+	paramAttr:    true,
+	headerAttr:   true,
+	successAttr:  true,
+	failureAttr:  true,
+	responseAttr: true,
+	useAttr:      true,
+}
+
+// fragment the annotations of a fragment, with the file declaring it, which the types they name are resolved in.
+type fragment struct {
+	lines []string
+	file  *ast.File
+}
+
+// isFragmentLine whether a line of the general API info belongs to the fragment declared above it,
+// the fragment consists of the annotations of fragmentAttributes following @fragment, usually indented.
+func isFragmentLine(commentLine string) bool {
+	fields := FieldsByAnySpace(strings.TrimSpace(commentLine), 2)
+
+	return len(fields) > 0 && fragmentAttributes[strings.ToLower(fields[0])]
+}
+
+// parseFragment parses a named fragment of the general API info, the annotations following @fragment, e.g.
+//
+//	// @fragment paginated
+//	//   @Param  page     query int false "page"
+//	//   @Param  per_page query int false "items per page"
+//	//   @Header 200 {string} Link "pagination links"
+func (parser *Parser) parseFragment(name string, comments []string, line *int) error {
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("invalid fragment name %q", name)
+	}
+	if _, ok := parser.fragments[name]; ok {
+		return fmt.Errorf("fragment %s is declared multiple times", name)
+	}
+
+	var lines []string
+	for *line+1 < len(comments) && isFragmentLine(comments[*line+1]) {
+		*line++
+		lines = append(lines, strings.TrimSpace(comments[*line]))
+	}
+
+	if len(lines) == 0 {
+		return fmt.Errorf("fragment %s has no annotations", name)
+	}
+
+	if parser.fragments == nil {
+		parser.fragments = make(map[string]fragment)
+	}
+	parser.fragments[name] = fragment{lines: lines, file: parser.generalInfoFile}
+
+	return nil
+}
+
+// ParseUseComment expands the fragments an operation uses in place, e.g. @Use paginated errors.
+func (operation *Operation) ParseUseComment(commentLine string, astFile *ast.File) error {
+	names := strings.FieldsFunc(commentLine, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(names) == 0 {
+		return fmt.Errorf("@Use needs a fragment name")
+	}
+
+	for _, name := range names {
+		fragment, ok := operation.parser.fragments[name]
+		if !ok {
+			return fmt.Errorf("unknown fragment %s", name)
+		}
+
+		// the types of a fragment are named in the scope of the file declaring it
+		file := fragment.file
+		if file == nil {
+			file = astFile
+		}
+
+		for _, used := range operation.usedFragments {
+			if used == name {
+				return fmt.Errorf("fragment %s uses itself", name)
+			}
+		}
+
+		operation.usedFragments = append(operation.usedFragments, name)
+		for _, line := range fragment.lines {
+			err := operation.ParseComment(line, file)
+			if err != nil {
+				return fmt.Errorf("fragment %s: %w", name, err)
+			}
+		}
+		operation.usedFragments = operation.usedFragments[:len(operation.usedFragments)-1]
+	}
+
+	return nil
+}
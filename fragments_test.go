@@ -0,0 +1,190 @@
+package swag
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFragments(t *testing.T) {
+	t.Parallel()
+
+	comments := []string{
+		"@title Accounts API",
+		"@fragment paginated",
+		"  @Param  page     query int false \"page\"",
+		"  @Param  per_page query int false \"items per page\"",
+		"  @Header 200 {string} Link \"pagination links\"",
+		"",
+		"@fragment errors",
+		"\t@Failure 400 {string} string \"bad request\"",
+		"\t@Use    notFound",
+		"@fragment notFound",
+		"  @Failure 404 {string} string \"not found\"",
+		"@version 1.0",
+	}
+	assert.True(t, isGeneralAPIComment(comments))
+
+	p := New()
+	assert.NoError(t, parseGeneralAPIInfo(p, comments))
+	assert.Equal(t, "1.0", p.swagger.Info.Version)
+	assert.Len(t, p.fragments, 3)
+
+	operation := NewOperation(p)
+	for _, comment := range []string{
+		"// @Summary List accounts",
+		"// @Success 200 {array} string",
+		"// @Use paginated, errors",
+		"// @Param q query string false \"query\"",
+	} {
+		assert.NoError(t, operation.ParseComment(comment, nil))
+	}
+
+	expected := `{
+   "summary": "List accounts",
+   "parameters": [
+      {
+         "type": "integer",
+         "description": "page",
+         "name": "page",
+         "in": "query"
+      },
+      {
+         "type": "integer",
+         "description": "items per page",
+         "name": "per_page",
+         "in": "query"
+      },
+      {
+         "type": "string",
+         "description": "query",
+         "name": "q",
+         "in": "query"
+      }
+   ],
+   "responses": {
+      "200": {
+         "description": "OK",
+         "schema": {
+            "type": "array",
+            "items": {
+               "type": "string"
+            }
+         },
+         "headers": {
+            "Link": {
+               "type": "string",
+               "description": "pagination links"
+            }
+         }
+      },
+      "400": {
+         "description": "bad request",
+         "schema": {
+            "type": "string"
+         }
+      },
+      "404": {
+         "description": "not found",
+         "schema": {
+            "type": "string"
+         }
+      }
+   }
+}`
+	b, err := json.MarshalIndent(operation, "", "   ")
+	assert.NoError(t, err)
+	assert.JSONEq(t, expected, string(b))
+
+	err = NewOperation(p).ParseComment("// @Use unknown", nil)
+	assert.EqualError(t, err, "unknown fragment unknown")
+}
+
+func TestParseFragmentsFormatted(t *testing.T) {
+	t.Parallel()
+
+	// swag fmt writes every annotation as //\t@attr, so indentation does not delimit a fragment
+	comments := []string{
+		"\t@title\tAccounts API",
+		"\t@fragment\tnotFound",
+		"\t@Failure\t404\t{string}\tstring\t\"not found\"",
+		"\t@version\t1.0",
+		"\t@accept\tjson",
+	}
+	assert.True(t, isGeneralAPIComment(comments))
+
+	p := New()
+	assert.NoError(t, parseGeneralAPIInfo(p, comments))
+	assert.Equal(t, "1.0", p.swagger.Info.Version)
+	assert.Equal(t, []string{"application/json"}, p.swagger.Consumes)
+	assert.Equal(t, []string{"@Failure\t404\t{string}\tstring\t\"not found\""}, p.fragments["notFound"].lines)
+}
+
+func TestParseFragmentsError(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	err := parseGeneralAPIInfo(p, []string{"@fragment empty", "@version 1.0"})
+	assert.EqualError(t, err, "fragment empty has no annotations")
+
+	p = New()
+	err = parseGeneralAPIInfo(p, []string{"@fragment twice", " @Use a", "@fragment twice", " @Use b"})
+	assert.EqualError(t, err, "fragment twice is declared multiple times")
+
+	p = New()
+	assert.NoError(t, parseGeneralAPIInfo(p, []string{"@fragment loop", " @Use loop"}))
+	err = NewOperation(p).ParseComment("// @Use loop", nil)
+	assert.EqualError(t, err, "fragment loop: fragment loop uses itself")
+}
+
+func TestParseFragmentsInDeclaringFile(t *testing.T) {
+	t.Parallel()
+
+	httputil := `
+package httputil
+
+type HTTPError struct {
+	Code int ` + "`json:\"code\"`" + `
+}
+`
+	main := `
+package main
+
+import errs "example.com/svc/httputil"
+
+var _ errs.HTTPError
+
+// @title Accounts API
+// @fragment errors
+//   @Failure 400 {object} errs.HTTPError "bad request"
+func main() {
+}
+`
+	api := `
+package api
+
+// @Use errors
+// @Router /accounts [get]
+func List() {
+}
+`
+	mainPath := filepath.Join(t.TempDir(), "main.go")
+	assert.NoError(t, os.WriteFile(mainPath, []byte(main), 0o644))
+
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("example.com/svc/httputil", "httputil/httputil.go", httputil, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("example.com/svc", mainPath, main, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("example.com/svc/api", "api/api.go", api, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.ParseGeneralAPIInfo(mainPath))
+
+	// the file using the fragment does not import errs
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+	response := p.swagger.Paths.Paths["/accounts"].Get.Responses.StatusCodeResponses[400]
+	assert.Equal(t, "#/definitions/httputil.HTTPError", response.Schema.Ref.String())
+}
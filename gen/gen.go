@@ -152,6 +152,36 @@ type Config struct {
 
 	// ParseFuncBody whether swag should parse api info inside of funcs
 	ParseFuncBody bool
+
+	// StringEnums whether integer enums with a String() method are documented by their string values
+	StringEnums bool
+
+	// EmbeddedAllOf whether embedded structs are composed with allOf instead of flattened
+	EmbeddedAllOf bool
+
+	// OpenAPIVersion the version of the generated document, 3.0 (default) or 3.1
+	OpenAPIVersion string
+
+	// ParseProtobuf whether structs generated by protoc-gen-go are documented as protojson encodes them
+	ParseProtobuf bool
+
+	// DefinitionNaming the naming strategy of definitions: full, short, path:N or a Go template
+	DefinitionNaming string
+
+	// StrictObjects whether the schemas of structs forbid the properties they do not declare
+	StrictObjects bool
+
+	// InferRoutes whether the routes of the operations without @Router are inferred from the code registering their handlers
+	InferRoutes bool
+
+	// InferHandlerTypes whether the body param and the responses an operation lacks are inferred from the body of its handler
+	InferHandlerTypes bool
+
+	// APIVersion the version of the API to generate the docs of, by the @Since and @Until of the operations and the fields
+	APIVersion string
+
+	// OperationIDNaming the naming strategy of the operationIds of the operations without @ID: func, receiver, path or a Go template
+	OperationIDNaming string
 }
 
 // Build builds swagger json file  for given searchDir and mainAPIFile. Returns json.
@@ -197,6 +227,16 @@ func (g *Gen) Build(config *Config) error {
 		}
 	}
 
+	definitionNamer, err := swag.DefinitionNamer(config.DefinitionNaming)
+	if err != nil {
+		return err
+	}
+
+	operationIDNamer, err := swag.OperationIDNamer(config.OperationIDNaming)
+	if err != nil {
+		return err
+	}
+
 	g.debug.Printf("Generate swagger docs....")
 
 	p := swag.New(
@@ -213,6 +253,16 @@ func (g *Gen) Build(config *Config) error {
 		swag.SetTags(config.Tags),
 		swag.SetCollectionFormat(config.CollectionFormat),
 		swag.SetPackagePrefix(config.PackagePrefix),
+		swag.SetStringEnums(config.StringEnums),
+		swag.SetEmbeddedAllOf(config.EmbeddedAllOf),
+		swag.SetOpenAPIVersion(config.OpenAPIVersion),
+		swag.SetParseProtobuf(config.ParseProtobuf),
+		swag.SetDefinitionNameFunc(definitionNamer),
+		swag.SetStrictObjects(config.StrictObjects),
+		swag.SetInferRoutes(config.InferRoutes),
+		swag.SetInferHandlerTypes(config.InferHandlerTypes),
+		swag.SetAPIVersion(config.APIVersion),
+		swag.SetOperationIDNameFunc(operationIDNamer),
 	)
 
 	p.PropNamingStrategy = config.PropNamingStrategy
@@ -308,7 +358,7 @@ func (g *Gen) writeJSONSwagger(config *Config, swagger *spec.Swagger) error {
 	}
 
 	// Convert to OpenAPI 3.0 format if needed
-	if swagger.Swagger == "3.0.0" {
+	if strings.HasPrefix(swagger.Swagger, "3.") {
 		b, err = g.convertToOpenAPI3(b)
 		if err != nil {
 			return err
@@ -344,7 +394,7 @@ func (g *Gen) writeYAMLSwagger(config *Config, swagger *spec.Swagger) error {
 	}
 
 	// Convert to OpenAPI 3.0 format if needed
-	if swagger.Swagger == "3.0.0" {
+	if strings.HasPrefix(swagger.Swagger, "3.") {
 		b, err = g.convertToOpenAPI3(b)
 		if err != nil {
 			return err
@@ -493,7 +543,7 @@ func (g *Gen) writeGoDoc(packageName string, output io.Writer, swagger *spec.Swa
 	generator, err := template.New("swagger_info").Funcs(template.FuncMap{
 		"printDoc": func(v string) string {
 			// For OpenAPI 3.0, schemes are part of servers array, so don't add them separately
-			if swagger.Swagger == "3.0.0" {
+			if strings.HasPrefix(swagger.Swagger, "3.") {
 				// Just sanitize backticks for OpenAPI 3.0 - schemes are already handled in servers
 				return strings.Replace(v, "`", "`+\"`\"+`", -1)
 			} else {
@@ -546,7 +596,7 @@ func (g *Gen) writeGoDoc(packageName string, output io.Writer, swagger *spec.Swa
 	}
 
 	// Convert to OpenAPI 3.0 format if needed (for template)
-	if swagger.Swagger == "3.0.0" {
+	if strings.HasPrefix(swagger.Swagger, "3.") {
 		buf, err = g.convertToOpenAPI3(buf)
 		if err != nil {
 			return err
@@ -658,12 +708,41 @@ func (g *Gen) convertToOpenAPI3(input []byte) ([]byte, error) {
 	delete(doc, "consumes")
 	delete(doc, "produces")
 
+	if doc["openapi"] == "3.1.0" {
+		g.convertKeyTypesToPropertyNames(doc)
+		g.convertSchemasToOpenAPI31(doc)
+	}
+
 	return json.MarshalIndent(doc, "", "    ")
 }
 
+// pathItemExtensions the extensions of a path item keeping the fields Swagger 2.0 lacks, by the fields of OpenAPI 3.
+var pathItemExtensions = map[string]string{
+	"x-trace":       "trace",
+	"x-summary":     "summary",
+	"x-description": "description",
+	"x-servers":     "servers",
+}
+
 func (g *Gen) convertPathsToOpenAPI3(paths map[string]interface{}) {
 	for _, pathValue := range paths {
 		if pathObj, ok := pathValue.(map[string]interface{}); ok {
+			for extension, field := range pathItemExtensions {
+				if value, ok := pathObj[extension]; ok {
+					pathObj[field] = value
+					delete(pathObj, extension)
+				}
+			}
+
+			// the params shared by the operations of the path
+			if parameters, ok := pathObj["parameters"].([]interface{}); ok {
+				for _, param := range parameters {
+					if paramObj, ok := param.(map[string]interface{}); ok {
+						g.convertParameterToOpenAPI3(paramObj)
+					}
+				}
+			}
+
 			for _, methodValue := range pathObj {
 				if methodObj, ok := methodValue.(map[string]interface{}); ok {
 					g.convertOperationToOpenAPI3(methodObj)
@@ -674,6 +753,9 @@ func (g *Gen) convertPathsToOpenAPI3(paths map[string]interface{}) {
 }
 
 func (g *Gen) convertOperationToOpenAPI3(operation map[string]interface{}) {
+	// Convert formData parameters to a form requestBody
+	g.convertFormDataToRequestBody(operation)
+
 	// Convert consumes to requestBody
 	if consumes, ok := operation["consumes"].([]interface{}); ok {
 		if parameters, hasParams := operation["parameters"].([]interface{}); hasParams {
@@ -728,16 +810,22 @@ func (g *Gen) convertOperationToOpenAPI3(operation map[string]interface{}) {
 			for _, response := range responses {
 				if responseObj, ok := response.(map[string]interface{}); ok {
 					if schema, hasSchema := responseObj["schema"]; hasSchema {
+						examples, _ := responseObj["examples"].(map[string]interface{})
 						content := map[string]interface{}{}
 						for _, produce := range produces {
 							if produceStr, ok := produce.(string); ok {
-								content[produceStr] = map[string]interface{}{
+								mediaType := map[string]interface{}{
 									"schema": schema,
 								}
+								if example, ok := responseExample(examples, produceStr); ok {
+									mediaType["example"] = example
+								}
+								content[produceStr] = mediaType
 							}
 						}
 						responseObj["content"] = content
 						delete(responseObj, "schema")
+						delete(responseObj, "examples")
 					}
 				}
 			}
@@ -755,13 +843,130 @@ func (g *Gen) convertOperationToOpenAPI3(operation map[string]interface{}) {
 	}
 }
 
+// convertFormDataToRequestBody moves the formData parameters of an operation into the schema of a form requestBody.
+// A file becomes a binary string, a part with a schema, such as a struct, is sent as JSON in a multipart form.
+func (g *Gen) convertFormDataToRequestBody(operation map[string]interface{}) {
+	parameters, ok := operation["parameters"].([]interface{})
+	if !ok {
+		return
+	}
+
+	properties := map[string]interface{}{}
+	encoding := map[string]interface{}{}
+	var required, others []interface{}
+	multipart := false
+
+	for _, param := range parameters {
+		paramObj, ok := param.(map[string]interface{})
+		if !ok || paramObj["in"] != "formData" {
+			others = append(others, param)
+			continue
+		}
+
+		name, _ := paramObj["name"].(string)
+		schema, isPart := paramObj["schema"].(map[string]interface{})
+		if isPart {
+			encoding[name] = map[string]interface{}{"contentType": "application/json"}
+			multipart = true
+		} else {
+			schema = map[string]interface{}{}
+			typeFields := []string{"type", "format", "enum", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "minLength", "maxLength", "pattern", "items", "default", "example", "maxItems", "minItems", "uniqueItems", "multipleOf"}
+			for _, field := range typeFields {
+				if value, exists := paramObj[field]; exists {
+					schema[field] = value
+				}
+			}
+		}
+
+		if schema["type"] == "file" {
+			schema["type"], schema["format"] = "string", "binary"
+			multipart = true
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok && items["type"] == "file" {
+			items["type"], items["format"] = "string", "binary"
+			multipart = true
+		}
+		if description, ok := paramObj["description"]; ok && description != "" {
+			schema["description"] = description
+		}
+
+		properties[name] = schema
+		if paramObj["required"] == true {
+			required = append(required, name)
+		}
+	}
+
+	if len(properties) == 0 {
+		return
+	}
+
+	if len(others) > 0 {
+		operation["parameters"] = others
+	} else {
+		delete(operation, "parameters")
+	}
+
+	var mediaTypes []string
+	if consumes, ok := operation["consumes"].([]interface{}); ok {
+		for _, consume := range consumes {
+			if consumeStr, ok := consume.(string); ok &&
+				(consumeStr == "multipart/form-data" || consumeStr == "application/x-www-form-urlencoded") {
+				mediaTypes = append(mediaTypes, consumeStr)
+			}
+		}
+		delete(operation, "consumes")
+	}
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{"application/x-www-form-urlencoded"}
+		if multipart {
+			mediaTypes = []string{"multipart/form-data"}
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	content := map[string]interface{}{}
+	for _, mediaType := range mediaTypes {
+		mediaTypeObj := map[string]interface{}{"schema": schema}
+		if mediaType == "multipart/form-data" && len(encoding) > 0 {
+			mediaTypeObj["encoding"] = encoding
+		}
+		content[mediaType] = mediaTypeObj
+	}
+
+	operation["requestBody"] = map[string]interface{}{
+		"required": len(required) > 0,
+		"content":  content,
+	}
+}
+
+// responseExample returns the example of a response for a media type,
+// the JSON example of an annotation serves all the JSON media types the operation produces.
+func responseExample(examples map[string]interface{}, mediaType string) (interface{}, bool) {
+	if example, ok := examples[mediaType]; ok {
+		return example, true
+	}
+	if strings.HasSuffix(mediaType, "json") {
+		example, ok := examples["application/json"]
+		return example, ok
+	}
+
+	return nil, false
+}
+
 func (g *Gen) convertParameterToOpenAPI3(param map[string]interface{}) {
 	// For non-body parameters, wrap type info in schema
 	if param["in"] != "body" && param["in"] != "formData" {
 		schema := map[string]interface{}{}
 
 		// Move type, format, enum, etc. to schema
-		typeFields := []string{"type", "format", "enum", "minimum", "maximum", "minLength", "maxLength", "pattern", "items", "default", "example"}
+		typeFields := []string{"type", "format", "enum", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "minLength", "maxLength", "pattern", "items", "default", "example"}
 		for _, field := range typeFields {
 			if value, exists := param[field]; exists {
 				schema[field] = value
@@ -793,6 +998,13 @@ func (g *Gen) updateReferences(obj map[string]interface{}) {
 					obj[key] = strings.Replace(ref, "#/definitions/", "#/components/schemas/", 1)
 				}
 			}
+		} else if mapping, ok := value.(map[string]interface{}); ok && key == "mapping" {
+			// the schemas of the values of a discriminator
+			for name, ref := range mapping {
+				if ref, ok := ref.(string); ok && strings.HasPrefix(ref, "#/definitions/") {
+					mapping[name] = strings.Replace(ref, "#/definitions/", "#/components/schemas/", 1)
+				}
+			}
 		} else if subObj, ok := value.(map[string]interface{}); ok {
 			g.updateReferences(subObj)
 		} else if arr, ok := value.([]interface{}); ok {
@@ -805,6 +1017,151 @@ func (g *Gen) updateReferences(obj map[string]interface{}) {
 	}
 }
 
+// convertKeyTypesToPropertyNames replaces the x-key-type extension of map schemas by a propertyNames schema (OpenAPI 3.1)
+func (g *Gen) convertKeyTypesToPropertyNames(obj map[string]interface{}) {
+	if keyType, ok := obj["x-key-type"].(map[string]interface{}); ok {
+		obj["propertyNames"] = propertyNamesSchema(keyType)
+		delete(obj, "x-key-type")
+	}
+
+	for _, value := range obj {
+		if subObj, ok := value.(map[string]interface{}); ok {
+			g.convertKeyTypesToPropertyNames(subObj)
+		} else if arr, ok := value.([]interface{}); ok {
+			for _, item := range arr {
+				if itemObj, ok := item.(map[string]interface{}); ok {
+					g.convertKeyTypesToPropertyNames(itemObj)
+				}
+			}
+		}
+	}
+}
+
+// convertSchemasToOpenAPI31 converts the schemas of a document, the ones of components.schemas and the ones
+// under a schema key of the paths, to the JSON Schema of OpenAPI 3.1.
+func (g *Gen) convertSchemasToOpenAPI31(doc map[string]interface{}) {
+	if components, ok := doc["components"].(map[string]interface{}); ok {
+		if schemas, ok := components["schemas"].(map[string]interface{}); ok {
+			for _, schema := range schemas {
+				convertSchemaToOpenAPI31(schema)
+			}
+		}
+	}
+
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			for key, item := range value {
+				if key == "schema" {
+					convertSchemaToOpenAPI31(item)
+				} else {
+					walk(item)
+				}
+			}
+		case []interface{}:
+			for _, item := range value {
+				walk(item)
+			}
+		}
+	}
+	walk(doc["paths"])
+}
+
+// convertSchemaToOpenAPI31 converts the keywords of a schema and its subschemas which JSON Schema 2020-12 changed:
+// a boolean exclusiveMinimum or exclusiveMaximum becomes the number of minimum or maximum,
+// nullable becomes the null type and example becomes examples.
+func convertSchemaToOpenAPI31(value interface{}) {
+	schema, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for exclusive, bound := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+		if isExclusive, ok := schema[exclusive].(bool); ok {
+			delete(schema, exclusive)
+			if limit, ok := schema[bound]; ok && isExclusive {
+				schema[exclusive] = limit
+				delete(schema, bound)
+			}
+		}
+	}
+
+	if example, ok := schema["example"]; ok {
+		schema["examples"] = []interface{}{example}
+		delete(schema, "example")
+	}
+
+	if nullable, ok := schema["nullable"].(bool); ok {
+		delete(schema, "nullable")
+		if nullable {
+			switch schemaType := schema["type"].(type) {
+			case string:
+				schema["type"] = []interface{}{schemaType, "null"}
+			case []interface{}:
+				schema["type"] = append(schemaType, "null")
+			default:
+				// a $ref or a composition without a type
+				nonNull := make(map[string]interface{}, len(schema))
+				for key, item := range schema {
+					nonNull[key] = item
+					delete(schema, key)
+				}
+				schema["anyOf"] = []interface{}{nonNull, map[string]interface{}{"type": "null"}}
+			}
+		}
+	}
+
+	for _, key := range []string{"items", "additionalProperties", "not", "propertyNames", "contains", "if", "then", "else"} {
+		convertSchemaToOpenAPI31(schema[key])
+	}
+	for _, key := range []string{"properties", "patternProperties", "$defs"} {
+		if schemas, ok := schema[key].(map[string]interface{}); ok {
+			for _, subschema := range schemas {
+				convertSchemaToOpenAPI31(subschema)
+			}
+		}
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf", "prefixItems"} {
+		if schemas, ok := schema[key].([]interface{}); ok {
+			for _, subschema := range schemas {
+				convertSchemaToOpenAPI31(subschema)
+			}
+		}
+	}
+}
+
+// propertyNamesSchema describes the text of map keys of the given type, as object keys are always strings in JSON.
+func propertyNamesSchema(keyType map[string]interface{}) map[string]interface{} {
+	names := map[string]interface{}{"type": "string"}
+
+	switch keyType["type"] {
+	case "integer":
+		names["pattern"] = `^-?[0-9]+$`
+	case "number":
+		names["pattern"] = `^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
+	case "boolean":
+		names["enum"] = []interface{}{"true", "false"}
+	default:
+		for _, key := range []string{"format", "pattern", "minLength", "maxLength"} {
+			if value, ok := keyType[key]; ok {
+				names[key] = value
+			}
+		}
+	}
+
+	if enum, ok := keyType["enum"].([]interface{}); ok {
+		values := make([]interface{}, 0, len(enum))
+		for _, value := range enum {
+			values = append(values, fmt.Sprint(value))
+		}
+		names["enum"] = values
+		delete(names, "pattern")
+	}
+
+	return names
+}
+
 var packageTemplate = `// Package {{.PackageName}} Code generated by swaggo/swag{{ if .GeneratedTime }} at {{ .Timestamp }}{{ end }}. DO NOT EDIT
 package {{.PackageName}}
 
@@ -1012,3 +1012,264 @@ func TestGen_FileDescriptionDirective(t *testing.T) {
 
 	assert.JSONEq(t, string(expectedJSON), string(jsonOutput))
 }
+
+func TestGen_convertKeyTypesToPropertyNames(t *testing.T) {
+	input := []byte(`{
+    "swagger": "3.1.0",
+    "definitions": {
+        "api.Report": {
+            "type": "object",
+            "properties": {
+                "amounts": {
+                    "type": "object",
+                    "additionalProperties": {"type": "number"},
+                    "x-key-type": {"type": "string", "enum": ["EUR", "USD"]}
+                },
+                "stats": {
+                    "type": "object",
+                    "additionalProperties": {"type": "integer"},
+                    "x-key-type": {"type": "integer", "format": "int64"}
+                }
+            }
+        }
+    }
+}`)
+
+	output, err := New().convertToOpenAPI3(input)
+	require.NoError(t, err)
+
+	var doc struct {
+		OpenAPI    string `json:"openapi"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(output, &doc))
+
+	properties := doc.Components.Schemas["api.Report"].Properties
+	assert.Equal(t, "3.1.0", doc.OpenAPI)
+	assert.NotContains(t, properties["amounts"], "x-key-type")
+	assert.Equal(t, map[string]interface{}{"type": "string", "enum": []interface{}{"EUR", "USD"}}, properties["amounts"]["propertyNames"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "pattern": "^-?[0-9]+$"}, properties["stats"]["propertyNames"])
+}
+
+func TestGen_convertResponseExamples(t *testing.T) {
+	input := []byte(`{
+    "swagger": "3.0.0",
+    "paths": {
+        "/users": {
+            "get": {
+                "produces": ["application/json", "text/xml"],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {"$ref": "#/definitions/model.User"},
+                        "examples": {"application/json": {"name": "Gopher"}}
+                    }
+                }
+            }
+        }
+    }
+}`)
+
+	output, err := New().convertToOpenAPI3(input)
+	require.NoError(t, err)
+
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]map[string]interface{} `json:"responses"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(output, &doc))
+
+	response := doc.Paths["/users"]["get"].Responses["200"]
+	assert.NotContains(t, response, "examples")
+
+	content := response["content"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "Gopher"}, content["application/json"].(map[string]interface{})["example"])
+	assert.NotContains(t, content["text/xml"], "example")
+}
+
+func TestGen_convertFormDataToRequestBody(t *testing.T) {
+	input := []byte(`{
+    "swagger": "3.0.0",
+    "paths": {
+        "/uploads": {
+            "post": {
+                "consumes": ["multipart/form-data"],
+                "parameters": [
+                    {"type": "string", "name": "id", "in": "path", "required": true},
+                    {"type": "string", "name": "name", "in": "formData", "required": true, "maxLength": 10},
+                    {"type": "file", "name": "file", "in": "formData", "required": true, "description": "the file"},
+                    {"type": "array", "items": {"type": "file"}, "name": "attachments", "in": "formData"},
+                    {"name": "meta", "in": "formData", "schema": {"$ref": "#/definitions/api.Meta"}}
+                ],
+                "responses": {"200": {"description": "OK"}}
+            }
+        }
+    }
+}`)
+
+	output, err := New().convertToOpenAPI3(input)
+	require.NoError(t, err)
+
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Parameters  []map[string]interface{} `json:"parameters"`
+			RequestBody map[string]interface{}   `json:"requestBody"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(output, &doc))
+
+	operation := doc.Paths["/uploads"]["post"]
+	require.Len(t, operation.Parameters, 1)
+	assert.Equal(t, "path", operation.Parameters[0]["in"])
+
+	expected := `{
+    "content": {
+        "multipart/form-data": {
+            "encoding": {
+                "meta": {
+                    "contentType": "application/json"
+                }
+            },
+            "schema": {
+                "properties": {
+                    "attachments": {
+                        "items": {
+                            "format": "binary",
+                            "type": "string"
+                        },
+                        "type": "array"
+                    },
+                    "file": {
+                        "description": "the file",
+                        "format": "binary",
+                        "type": "string"
+                    },
+                    "meta": {
+                        "$ref": "#/components/schemas/api.Meta"
+                    },
+                    "name": {
+                        "maxLength": 10,
+                        "type": "string"
+                    }
+                },
+                "required": [
+                    "name",
+                    "file"
+                ],
+                "type": "object"
+            }
+        }
+    },
+    "required": true
+}`
+	b, _ := json.MarshalIndent(operation.RequestBody, "", "    ")
+	assert.Equal(t, expected, string(b))
+}
+
+func TestGen_updateDiscriminatorMapping(t *testing.T) {
+	input := []byte(`{
+    "swagger": "3.0.0",
+    "definitions": {
+        "api.Pet": {
+            "type": "object",
+            "discriminator": {
+                "propertyName": "kind",
+                "mapping": {"dog": "#/definitions/api.Dog"}
+            }
+        }
+    }
+}`)
+
+	output, err := New().convertToOpenAPI3(input)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), `"dog": "#/components/schemas/api.Dog"`)
+}
+
+func TestGen_convertPathItems(t *testing.T) {
+	input := []byte(`{
+    "swagger": "3.0.0",
+    "paths": {
+        "/items/{id}": {
+            "x-summary": "An item",
+            "x-description": "The item of an id",
+            "x-servers": [{"url": "https://eu.example.com/api", "description": "EU"}],
+            "parameters": [{"type": "integer", "name": "id", "in": "path", "required": true}],
+            "x-trace": {
+                "produces": ["message/http"],
+                "responses": {"200": {"description": "OK", "schema": {"type": "string"}}}
+            }
+        }
+    }
+}`)
+
+	output, err := New().convertToOpenAPI3(input)
+	require.NoError(t, err)
+
+	var doc struct {
+		Paths map[string]map[string]interface{} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(output, &doc))
+
+	item := doc.Paths["/items/{id}"]
+	assert.Equal(t, "An item", item["summary"])
+	assert.Equal(t, "The item of an id", item["description"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "https://eu.example.com/api", "description": "EU"}}, item["servers"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"name": "id", "in": "path", "required": true, "schema": map[string]interface{}{"type": "integer"},
+	}}, item["parameters"])
+	assert.NotContains(t, item, "x-trace")
+	assert.Contains(t, item["trace"].(map[string]interface{})["responses"].(map[string]interface{})["200"], "content")
+}
+
+func TestGen_convertSchemasToOpenAPI31(t *testing.T) {
+	input := []byte(`{
+    "swagger": "3.1.0",
+    "paths": {
+        "/items": {
+            "get": {
+                "parameters": [{"type": "integer", "name": "limit", "in": "query", "minimum": 0, "exclusiveMinimum": true}],
+                "responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/api.Item"}}}
+            }
+        }
+    },
+    "definitions": {
+        "api.Item": {
+            "type": "object",
+            "properties": {
+                "price": {"type": "number", "minimum": 0, "exclusiveMinimum": true, "maximum": 10, "exclusiveMaximum": false},
+                "name": {"type": "string", "nullable": true, "example": "Gopher"},
+                "parent": {"$ref": "#/definitions/api.Item", "nullable": true}
+            }
+        }
+    }
+}`)
+
+	output, err := New().convertToOpenAPI3(input)
+	require.NoError(t, err)
+
+	var doc struct {
+		Paths      map[string]map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(output, &doc))
+
+	properties := doc.Components.Schemas["api.Item"].Properties
+	assert.Equal(t, map[string]interface{}{"type": "number", "exclusiveMinimum": float64(0), "maximum": float64(10)}, properties["price"])
+	assert.Equal(t, map[string]interface{}{"type": []interface{}{"string", "null"}, "examples": []interface{}{"Gopher"}}, properties["name"])
+	assert.Equal(t, map[string]interface{}{"anyOf": []interface{}{
+		map[string]interface{}{"$ref": "#/components/schemas/api.Item"},
+		map[string]interface{}{"type": "null"},
+	}}, properties["parent"])
+
+	param := doc.Paths["/items"]["get"]["parameters"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "integer", "exclusiveMinimum": float64(0)}, param["schema"])
+}
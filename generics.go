@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/token"
+	"reflect"
 	"strings"
 	"unicode"
 
@@ -16,6 +18,9 @@ import (
 type genericTypeSpec struct {
 	TypeSpec *TypeSpecDef
 	Name     string
+
+	// Constraint the constraint of a type parameter which is not instantiated
+	Constraint ast.Expr
 }
 
 type formalParamType struct {
@@ -156,6 +161,13 @@ func (pkgDefs *PackagesDefinitions) parametrizeGenericType(file *ast.File, origi
 		}
 	}
 
+	if original.TypeSpec.Assign.IsValid() {
+		// type Alias[T any] = Target[T]
+		if target := pkgDefs.resolveGenericAlias(original, genericParamTypeDefs); target != nil {
+			return target
+		}
+	}
+
 	name = fmt.Sprintf("%s%s-", string(IgnoreNameOverridePrefix), original.TypeName())
 	schemaName := fmt.Sprintf("%s-", original.SchemaName)
 
@@ -195,7 +207,9 @@ func (pkgDefs *PackagesDefinitions) parametrizeGenericType(file *ast.File, origi
 			Doc:    original.TypeSpec.Doc,
 			Assign: original.TypeSpec.Assign,
 		},
-		SchemaName: schemaName,
+		SchemaName:  schemaName,
+		genericBase: original,
+		typeArgs:    genericParams,
 	}
 	pkgDefs.uniqueDefinitions[name] = parametrizedTypeSpec
 
@@ -204,6 +218,89 @@ func (pkgDefs *PackagesDefinitions) parametrizeGenericType(file *ast.File, origi
 	return parametrizedTypeSpec
 }
 
+// resolveGenericAlias resolves a generic type alias to the instantiation of its target type.
+func (pkgDefs *PackagesDefinitions) resolveGenericAlias(original *TypeSpecDef, genericParamTypeDefs map[string]*genericTypeSpec) *TypeSpecDef {
+	switch original.TypeSpec.Type.(type) {
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		fullGenericName, err := getGenericFieldType(original.File, original.TypeSpec.Type, genericParamTypeDefs)
+		if err != nil {
+			return nil
+		}
+		return pkgDefs.FindTypeSpec(fullGenericName, original.File)
+	}
+	return nil
+}
+
+// resolveTypeParamConstraints replaces the type parameters of a generic type which is not instantiated by their constraints.
+func (pkgDefs *PackagesDefinitions) resolveTypeParamConstraints(typeSpecDef *TypeSpecDef) ast.Expr {
+	genericParamTypeDefs := map[string]*genericTypeSpec{}
+	for _, field := range typeSpecDef.TypeSpec.TypeParams.List {
+		constraint := pkgDefs.typeParamConstraint(typeSpecDef.File, field.Type)
+		for _, ident := range field.Names {
+			genericParamTypeDefs[ident.Name] = &genericTypeSpec{
+				Name:       ident.Name,
+				Constraint: constraint,
+			}
+		}
+	}
+	return pkgDefs.resolveGenericType(typeSpecDef.File, typeSpecDef.TypeSpec.Type, genericParamTypeDefs)
+}
+
+// typeParamConstraint returns the type set of a type parameter constraint, e.g. `~int | ~string`,
+// or an empty interface if the constraint does not restrict the type set.
+func (pkgDefs *PackagesDefinitions) typeParamConstraint(file *ast.File, expr ast.Expr) ast.Expr {
+	anyType := &ast.InterfaceType{Methods: &ast.FieldList{}}
+
+	switch constraint := expr.(type) {
+	case *ast.BinaryExpr:
+		if constraint.Op != token.OR {
+			return anyType
+		}
+		return &ast.BinaryExpr{
+			X:  pkgDefs.typeParamConstraint(file, constraint.X),
+			Op: token.OR,
+			Y:  pkgDefs.typeParamConstraint(file, constraint.Y),
+		}
+	case *ast.UnaryExpr:
+		if constraint.Op != token.TILDE {
+			return anyType
+		}
+		return pkgDefs.typeParamConstraint(file, constraint.X)
+	case *ast.InterfaceType:
+		if constraint.Methods != nil {
+			for _, elem := range constraint.Methods.List {
+				if len(elem.Names) == 0 {
+					return pkgDefs.typeParamConstraint(file, elem.Type)
+				}
+			}
+		}
+		return anyType
+	case *ast.ArrayType, *ast.MapType, *ast.StarExpr, *ast.StructType:
+		return constraint
+	case *ast.Ident:
+		if constraint.Name == ANY || constraint.Name == "comparable" {
+			return anyType
+		}
+		if IsGolangPrimitiveType(constraint.Name) {
+			return constraint
+		}
+	}
+
+	typeName, err := getFieldType(file, expr, nil)
+	if err != nil {
+		return anyType
+	}
+	typeDef := pkgDefs.FindTypeSpec(typeName, file)
+	if typeDef == nil {
+		return anyType
+	}
+	if iface, ok := typeDef.TypeSpec.Type.(*ast.InterfaceType); ok {
+		// a named constraint, such as `type Number interface{ ~int | ~float64 }`
+		return pkgDefs.typeParamConstraint(typeDef.File, iface)
+	}
+	return expr
+}
+
 // splitGenericsTypeName splits a generic struct name in his parts
 func splitGenericsTypeName(fullGenericForm string) (string, []string) {
 	//remove all spaces character
@@ -246,6 +343,10 @@ func splitGenericsTypeName(fullGenericForm string) (string, []string) {
 }
 
 func (pkgDefs *PackagesDefinitions) getParametrizedType(genTypeSpec *genericTypeSpec) ast.Expr {
+	if genTypeSpec.Constraint != nil {
+		return genTypeSpec.Constraint
+	}
+
 	if genTypeSpec.TypeSpec != nil && strings.Contains(genTypeSpec.Name, ".") {
 		parts := strings.SplitN(genTypeSpec.Name, ".", 2)
 		return &ast.SelectorExpr{
@@ -431,6 +532,14 @@ func (parser *Parser) parseGenericTypeExpr(file *ast.File, typeExpr ast.Expr) (*
 	case *ast.ArrayType:
 	case *ast.MapType:
 	case *ast.FuncType:
+	case *ast.BinaryExpr:
+		if expr.Op == token.OR {
+			return parser.parseUnionTypeExpr(file, expr)
+		}
+	case *ast.UnaryExpr:
+		if expr.Op == token.TILDE {
+			return parser.parseTypeExpr(file, expr.X, true)
+		}
 	case *ast.IndexExpr, *ast.IndexListExpr:
 		name, err := getExtendedGenericFieldType(file, expr, nil)
 		if err == nil {
@@ -446,3 +555,102 @@ func (parser *Parser) parseGenericTypeExpr(file *ast.File, typeExpr ast.Expr) (*
 
 	return PrimitiveSchema(OBJECT), nil
 }
+
+// parseUnionTypeExpr parses the type set of a union constraint, e.g. `~int | ~string`. Its scalar members are merged
+// by their JSON types, the integers into a number, so a value matches one of them only: they are oneOf the members,
+// and anyOf them if some members overlap, e.g. two struct types.
+func (parser *Parser) parseUnionTypeExpr(file *ast.File, expr *ast.BinaryExpr) (*spec.Schema, error) {
+	var members []spec.Schema
+	scalars := make(map[string]int)
+
+nextTerm:
+	for _, term := range unionTerms(expr) {
+		schema, err := parser.parseTypeExpr(file, term, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if scalar := scalarType(schema); scalar != "" {
+			if scalar == INTEGER {
+				if _, ok := scalars[NUMBER]; ok {
+					continue
+				}
+			}
+			if i, ok := scalars[INTEGER]; ok && scalar == NUMBER {
+				// a number includes the integers
+				delete(scalars, INTEGER)
+				scalars[NUMBER] = i
+				members[i] = *PrimitiveSchema(NUMBER)
+
+				continue
+			}
+			if i, ok := scalars[scalar]; ok {
+				if members[i].Format != schema.Format {
+					members[i].Format = ""
+				}
+
+				continue
+			}
+
+			scalars[scalar] = len(members)
+			members = append(members, *schema)
+
+			continue
+		}
+
+		for _, known := range members {
+			if reflect.DeepEqual(known, *schema) {
+				continue nextTerm
+			}
+		}
+		members = append(members, *schema)
+	}
+
+	switch {
+	case len(members) == 1:
+		return &members[0], nil
+	case disjointTypes(members):
+		return &spec.Schema{SchemaProps: spec.SchemaProps{OneOf: members}}, nil
+	default:
+		return &spec.Schema{SchemaProps: spec.SchemaProps{AnyOf: members}}, nil
+	}
+}
+
+// disjointTypes whether no value matches two of schemas, as each of them has another JSON type.
+func disjointTypes(schemas []spec.Schema) bool {
+	types := make(map[string]bool)
+	for _, schema := range schemas {
+		if schema.Ref.String() != "" || len(schema.Type) != 1 || types[schema.Type[0]] {
+			return false
+		}
+		types[schema.Type[0]] = true
+	}
+
+	return true
+}
+
+// scalarType returns the JSON type of a schema of a scalar, which has a type and a format only, or an empty string.
+func scalarType(schema *spec.Schema) string {
+	if len(schema.Type) != 1 {
+		return ""
+	}
+
+	switch schema.Type[0] {
+	case INTEGER, NUMBER, STRING, BOOLEAN:
+		bare := *schema
+		bare.Type, bare.Format = nil, ""
+		if reflect.ValueOf(bare).IsZero() {
+			return schema.Type[0]
+		}
+	}
+
+	return ""
+}
+
+// unionTerms flattens the terms of a union `A | B | C`.
+func unionTerms(expr ast.Expr) []ast.Expr {
+	if union, ok := expr.(*ast.BinaryExpr); ok && union.Op == token.OR {
+		return append(unionTerms(union.X), unionTerms(union.Y)...)
+	}
+	return []ast.Expr{expr}
+}
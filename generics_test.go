@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/ast"
+	goparser "go/parser"
 	"os"
 	"path/filepath"
 	"testing"
@@ -134,6 +135,21 @@ func TestParseGenericsPackageAlias(t *testing.T) {
 	assert.Equal(t, string(expected), string(b))
 }
 
+func TestParseGenericsAlias(t *testing.T) {
+	t.Parallel()
+
+	searchDir := "testdata/generics_alias"
+	expected, err := os.ReadFile(filepath.Join(searchDir, "expected.json"))
+	assert.NoError(t, err)
+
+	p := New()
+	err = p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth)
+	assert.NoError(t, err)
+	b, err := json.MarshalIndent(p.swagger, "", "    ")
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(b))
+}
+
 func TestParseGenericsFunctionScoped(t *testing.T) {
 	t.Parallel()
 
@@ -448,3 +464,24 @@ func TestParseGenericTypeExpr(t *testing.T) {
 	assert.NotEmpty(t, logger.Messages)
 	assert.Len(t, logger.Messages, 1)
 }
+
+func TestParseUnionTypeExpr(t *testing.T) {
+	t.Parallel()
+
+	for union, expected := range map[string]string{
+		"~int | ~int64 | ~float64": `{"type":"number"}`,
+		"~int32 | ~int64":          `{"type":"integer"}`,
+		"~float32 | ~float32":      `{"type":"number","format":"float32"}`,
+		"~int | ~string":           `{"oneOf":[{"type":"integer"},{"type":"string"}]}`,
+		"[]int | []string":         `{"anyOf":[{"type":"array","items":{"type":"integer"}},{"type":"array","items":{"type":"string"}}]}`,
+	} {
+		expr, err := goparser.ParseExpr(union)
+		assert.NoError(t, err)
+
+		schema, err := New().parseTypeExpr(&ast.File{}, expr, true)
+		assert.NoError(t, err)
+		b, err := json.Marshal(schema)
+		assert.NoError(t, err)
+		assert.JSONEq(t, expected, string(b), union)
+	}
+}
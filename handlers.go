@@ -0,0 +1,668 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// requestBindings the methods of gin, echo and fiber contexts binding the request to their first argument,
+// by the param they read it from.
+var requestBindings = map[string]string{
+	"Bind":               "body",
+	"BindJSON":           "body",
+	"BindXML":            "body",
+	"BindYAML":           "body",
+	"ShouldBind":         "body",
+	"ShouldBindJSON":     "body",
+	"ShouldBindXML":      "body",
+	"ShouldBindYAML":     "body",
+	"ShouldBindBodyWith": "body",
+	"BodyParser":         "body",
+	"BindQuery":          "query",
+	"ShouldBindQuery":    "query",
+	"QueryParser":        "query",
+}
+
+// responseWriter the arguments of a method of a context writing a response, -1 if the method has no such argument.
+type responseWriter struct {
+	status int
+	data   int
+	// args the number of the arguments of the method, the least number for a variadic method
+	args     int
+	variadic bool
+	// dataOnly whether fiber has the method with the data as its only argument, after c.Status(code)
+	dataOnly bool
+}
+
+// responseWriters the methods of gin, echo and fiber contexts writing a response.
+var responseWriters = map[string]responseWriter{
+	"JSON":                {status: 0, data: 1, args: 2, dataOnly: true},
+	"IndentedJSON":        {status: 0, data: 1, args: 2},
+	"PureJSON":            {status: 0, data: 1, args: 2},
+	"SecureJSON":          {status: 0, data: 1, args: 2},
+	"AsciiJSON":           {status: 0, data: 1, args: 2},
+	"JSONPretty":          {status: 0, data: 1, args: 3},
+	"XML":                 {status: 0, data: 1, args: 2, dataOnly: true},
+	"String":              {status: 0, data: 1, args: 2, variadic: true},
+	"AbortWithStatusJSON": {status: 0, data: 1, args: 2},
+	"NoContent":           {status: 0, data: -1, args: 1},
+	"AbortWithStatus":     {status: 0, data: -1, args: 1},
+}
+
+// contextTypes the types of the contexts of gin, echo and fiber handlers, by the name of their package,
+// whose methods bind the request and write the responses.
+var contextTypes = map[string]bool{
+	"gin.Context":  true,
+	"echo.Context": true,
+	"fiber.Ctx":    true,
+}
+
+// httpStatusCodes the codes of the status constants of net/http and fiber, e.g. StatusNotFound.
+var httpStatusCodes = func() map[string]int {
+	codes := map[string]int{
+		"StatusNonAuthoritativeInfo": http.StatusNonAuthoritativeInfo,
+		"StatusProxyAuthRequired":    http.StatusProxyAuthRequired,
+		"StatusTeapot":               http.StatusTeapot,
+	}
+	for code := 100; code < 600; code++ {
+		name := strings.Map(func(r rune) rune {
+			if r == ' ' || r == '-' || r == '\'' {
+				return -1
+			}
+
+			return r
+		}, http.StatusText(code))
+		if name != "" {
+			codes["Status"+name] = code
+		}
+	}
+
+	return codes
+}()
+
+// maxHandlerDepth the depth of the helper functions a handler writes its responses with, e.g. httputil.NewError.
+const maxHandlerDepth = 2
+
+// handlerExpr an expression of the body of a handler, with the file it is written in.
+type handlerExpr struct {
+	file *AstFileInfo
+	expr ast.Expr
+}
+
+// inferredParam a param of a handler, bound by one of the requestBindings.
+type inferredParam struct {
+	in    string
+	name  string
+	value handlerExpr
+}
+
+// inferredResponse a response written by a handler, value is nil for a response without a body.
+type inferredResponse struct {
+	code  int
+	value *handlerExpr
+}
+
+// handlerInference what the body of a handler binds the request to and writes the responses with.
+type handlerInference struct {
+	parser    *Parser
+	params    []inferredParam
+	responses []inferredResponse
+}
+
+// inferHandlerTypes synthesizes the body param, the query params and the responses which an operation lacks
+// from the body of its handler, as gin, echo and fiber handlers bind the request and write the responses.
+func (parser *Parser) inferHandlerTypes(operation *Operation, comments []*ast.Comment, fileInfo *AstFileInfo) error {
+	name, funcType, body := handlerFunc(fileInfo.File, comments)
+	if body == nil {
+		return nil
+	}
+
+	inference := &handlerInference{parser: parser}
+	inference.readBody(fileInfo, funcType, body, nil, 0)
+
+	var lines []inferredLine
+	for _, param := range inference.params {
+		in := param.in
+		if in == "body" && !hasBody(operation.RouterProperties) {
+			// echo and gin bind the query of a request without a body
+			in = "query"
+		}
+		if hasParamIn(operation, in) || (in == "body" && hasParamIn(operation, "formData")) {
+			continue
+		}
+
+		typeName, ok := typeNameOf(param.value)
+		if !ok {
+			parser.debug.Printf("warning: could not infer the type of %s of %s", types.ExprString(param.value.expr), name)
+
+			continue
+		}
+
+		lines = append(lines, inferredLine{
+			text: fmt.Sprintf(`%s %s %s %s %t "%s"`, paramAttr, param.name, in, typeName, in == "body", param.name),
+			file: param.value.file,
+		})
+	}
+
+	for _, response := range inference.responses {
+		if operation.Responses != nil && operation.Responses.StatusCodeResponses != nil {
+			if _, ok := operation.Responses.StatusCodeResponses[response.code]; ok {
+				continue
+			}
+		}
+
+		attribute := successAttr
+		if response.code >= http.StatusBadRequest {
+			attribute = failureAttr
+		}
+
+		if response.value == nil {
+			lines = append(lines, inferredLine{text: fmt.Sprintf("%s %d", attribute, response.code), file: fileInfo})
+
+			continue
+		}
+
+		typeName, ok := typeNameOf(*response.value)
+		if !ok {
+			parser.debug.Printf("warning: could not infer the type of %s of %s", types.ExprString(response.value.expr), name)
+
+			continue
+		}
+
+		lines = append(lines, inferredLine{
+			text: fmt.Sprintf("%s %d {object} %s", attribute, response.code, typeName),
+			file: response.value.file,
+		})
+	}
+
+	for _, line := range lines {
+		err := operation.ParseComment(line.text, line.file.File)
+		if err != nil {
+			err = fmt.Errorf("could not infer %s of %s: %w", line.text, name, err)
+			if parser.Strict {
+				return err
+			}
+
+			parser.debug.Printf("warning: %s", err)
+
+			continue
+		}
+
+		parser.debug.Printf("Inferred %s of %s", line.text, name)
+	}
+
+	return nil
+}
+
+// inferredLine an annotation synthesized for a handler, the types it names are declared in the scope of file.
+type inferredLine struct {
+	text string
+	file *AstFileInfo
+}
+
+// handlerFunc returns the name, the type and the body of the handler an operation is the doc comment of,
+// a function declaration or a function literal registered as a handler.
+func handlerFunc(file *ast.File, comments []*ast.Comment) (string, *ast.FuncType, *ast.BlockStmt) {
+	if funcDecl := handlerDecl(file, comments); funcDecl != nil {
+		return funcDecl.Name.Name, funcDecl.Type, funcDecl.Body
+	}
+
+	var lit *ast.FuncLit
+	ast.Inspect(file, func(node ast.Node) bool {
+		if funcLit, ok := node.(*ast.FuncLit); ok && funcLit.Pos() > comments[len(comments)-1].End() &&
+			(lit == nil || funcLit.Pos() < lit.Pos()) {
+			lit = funcLit
+		}
+
+		return true
+	})
+	if lit == nil {
+		return "", nil, nil
+	}
+
+	doc := literalDoc(file, comments[0].Pos()-1, lit)
+	if doc == nil || doc.List[0] != comments[0] {
+		return "", nil, nil
+	}
+
+	return "function literal", lit.Type, lit.Body
+}
+
+// handlerDecl returns the declaration of the function an operation is the doc comment of.
+func handlerDecl(file *ast.File, comments []*ast.Comment) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if ok && funcDecl.Doc != nil && funcDecl.Doc.List[0] == comments[0] {
+			return funcDecl
+		}
+	}
+
+	return nil
+}
+
+// handlerOfDoc returns the handler an operation is the doc comment of, without a name for a function literal.
+func handlerOfDoc(fileInfo *AstFileInfo, comments []*ast.Comment) routeHandler {
+	if funcDecl := handlerDecl(fileInfo.File, comments); funcDecl != nil {
+		handler, _ := declaredHandler(fileInfo.PackagePath, funcDecl)
+
+		return handler
+	}
+
+	return routeHandler{pkgPath: fileInfo.PackagePath}
+}
+
+// readBody reads the bindings and the responses of the body of a handler, or of a function the handler calls
+// with args, such as httputil.NewError(ctx, http.StatusNotFound, err).
+func (inference *handlerInference) readBody(fileInfo *AstFileInfo, funcType *ast.FuncType, body *ast.BlockStmt, args []handlerExpr, depth int) {
+	// the arguments the parameters of the function are called with
+	params := make(map[*ast.Object]handlerExpr)
+	if funcType.Params != nil {
+		i := 0
+		for _, field := range funcType.Params.List {
+			for _, name := range field.Names {
+				if i < len(args) && name.Obj != nil {
+					params[name.Obj] = args[i]
+				}
+				i++
+			}
+		}
+	}
+
+	resolve := func(expr ast.Expr) handlerExpr {
+		if ident, ok := expr.(*ast.Ident); ok && ident.Obj != nil {
+			if arg, ok := params[ident.Obj]; ok {
+				return arg
+			}
+		}
+
+		return handlerExpr{file: fileInfo, expr: expr}
+	}
+
+	// the params of the function which are contexts
+	contexts := make(map[*ast.Object]bool)
+	if funcType.Params != nil {
+		for _, field := range funcType.Params.List {
+			if isContextType(fileInfo.File, field.Type) {
+				for _, name := range field.Names {
+					contexts[name.Obj] = true
+				}
+			}
+		}
+	}
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || isPackageName(fileInfo.File, selector.X) {
+			if depth+1 < maxHandlerDepth {
+				inference.readCall(fileInfo, call, resolve, depth)
+			}
+
+			return true
+		}
+
+		if !isContext(contexts, selector.X, 0) {
+			// a method of another value, e.g. String() of a strings.Builder
+			return true
+		}
+
+		if in, ok := requestBindings[selector.Sel.Name]; ok && len(call.Args) > 0 {
+			value := resolve(call.Args[0])
+			inference.params = append(inference.params, inferredParam{in: in, name: bindingName(value.expr), value: value})
+
+			return true
+		}
+
+		writer, ok := responseWriters[selector.Sel.Name]
+		if !ok {
+			return true
+		}
+
+		status := http.StatusOK
+		statusExpr := ast.Expr(nil)
+		switch {
+		case len(call.Args) == 1 && writer.dataOnly:
+			// fiber: c.Status(http.StatusCreated).JSON(item)
+			writer = responseWriter{status: -1, data: 0}
+			if statusCall, ok := selector.X.(*ast.CallExpr); ok && len(statusCall.Args) == 1 {
+				if statusSelector, ok := statusCall.Fun.(*ast.SelectorExpr); ok && statusSelector.Sel.Name == "Status" {
+					statusExpr = statusCall.Args[0]
+				}
+			}
+		case len(call.Args) == writer.args || (writer.variadic && len(call.Args) > writer.args):
+			statusExpr = call.Args[writer.status]
+		default:
+			// another method with the same name, e.g. String() of a type implementing fmt.Stringer
+			return true
+		}
+
+		if statusExpr != nil {
+			code, ok := inference.statusCode(resolve(statusExpr))
+			if !ok {
+				return true
+			}
+			status = code
+		}
+
+		response := inferredResponse{code: status}
+		if writer.data >= 0 && writer.data < len(call.Args) {
+			value := resolve(call.Args[writer.data])
+			response.value = &value
+		}
+		inference.addResponse(response)
+
+		return true
+	})
+}
+
+// isContextType whether a type is a context of gin, echo or fiber.
+func isContextType(file *ast.File, typ ast.Expr) bool {
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+
+	sel, ok := typ.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	path := importPath(file, pkg.Name)
+
+	return path != "" && contextTypes[importPathName(path)+"."+sel.Sel.Name]
+}
+
+// isContext whether an expression is one of the contexts a handler is called with, or a value derived from it,
+// e.g. a variable it is assigned to or the result of one of its methods, as c.Status(http.StatusCreated) of fiber.
+func isContext(contexts map[*ast.Object]bool, expr ast.Expr, depth int) bool {
+	if depth > maxHandlerDepth*4 {
+		return false
+	}
+
+	switch x := expr.(type) {
+	case *ast.ParenExpr:
+		return isContext(contexts, x.X, depth+1)
+	case *ast.CallExpr:
+		sel, ok := x.Fun.(*ast.SelectorExpr)
+
+		return ok && isContext(contexts, sel.X, depth+1)
+	case *ast.Ident:
+		if x.Obj == nil {
+			return false
+		}
+		if contexts[x.Obj] {
+			return true
+		}
+
+		switch decl := x.Obj.Decl.(type) {
+		case *ast.ValueSpec:
+			for i, name := range decl.Names {
+				if name.Obj == x.Obj && i < len(decl.Values) && len(decl.Values) == len(decl.Names) {
+					return isContext(contexts, decl.Values[i], depth+1)
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range decl.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && ident.Obj == x.Obj && len(decl.Rhs) == len(decl.Lhs) {
+					return isContext(contexts, decl.Rhs[i], depth+1)
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// readCall reads the responses of a function of the parsed packages called by a handler.
+func (inference *handlerInference) readCall(fileInfo *AstFileInfo, call *ast.CallExpr, resolve func(ast.Expr) handlerExpr, depth int) {
+	funcDecl, funcFile := inference.funcDecl(fileInfo, call.Fun)
+	if funcDecl == nil || funcDecl.Body == nil {
+		return
+	}
+
+	args := make([]handlerExpr, 0, len(call.Args))
+	for _, arg := range call.Args {
+		args = append(args, resolve(arg))
+	}
+
+	inference.readBody(funcFile, funcDecl.Type, funcDecl.Body, args, depth+1)
+}
+
+// funcDecl finds the declaration of a function of the parsed packages, e.g. NewError or httputil.NewError.
+func (inference *handlerInference) funcDecl(fileInfo *AstFileInfo, fun ast.Expr) (*ast.FuncDecl, *AstFileInfo) {
+	pkgPath, name := fileInfo.PackagePath, ""
+	switch f := fun.(type) {
+	case *ast.Ident:
+		if f.Obj != nil {
+			funcDecl, ok := f.Obj.Decl.(*ast.FuncDecl)
+			if !ok {
+				return nil, nil
+			}
+
+			return funcDecl, fileInfo
+		}
+		name = f.Name
+	case *ast.SelectorExpr:
+		pkg, ok := f.X.(*ast.Ident)
+		if !ok {
+			return nil, nil
+		}
+
+		matchedPkgPaths, _ := inference.parser.packages.findPackagePathFromImports(pkg.Name, fileInfo.File)
+		if len(matchedPkgPaths) == 0 {
+			return nil, nil
+		}
+		pkgPath, name = matchedPkgPaths[0], f.Sel.Name
+	default:
+		return nil, nil
+	}
+
+	pkg, ok := inference.parser.packages.packages[pkgPath]
+	if !ok {
+		return nil, nil
+	}
+
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv == nil && funcDecl.Name.Name == name {
+				return funcDecl, inference.parser.packages.files[file]
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// addResponse adds a response, unless the handler writes another one with the same status first.
+func (inference *handlerInference) addResponse(response inferredResponse) {
+	for _, other := range inference.responses {
+		if other.code == response.code {
+			return
+		}
+	}
+
+	inference.responses = append(inference.responses, response)
+}
+
+// statusCode evaluates the status of a response, e.g. 200, http.StatusNotFound, fiber.StatusOK or a constant.
+func (inference *handlerInference) statusCode(value handlerExpr) (int, bool) {
+	switch expr := value.expr.(type) {
+	case *ast.BasicLit:
+		if expr.Kind != token.INT {
+			return 0, false
+		}
+
+		code, err := strconv.Atoi(expr.Value)
+
+		return code, err == nil
+	case *ast.SelectorExpr:
+		if code, ok := httpStatusCodes[expr.Sel.Name]; ok {
+			return code, true
+		}
+	}
+
+	if value.file == nil {
+		return 0, false
+	}
+
+	result, err := inference.parser.evaluateLiteral(value.file.File, nil, value.expr)
+	if err != nil {
+		return 0, false
+	}
+
+	code, err := strconv.Atoi(fmt.Sprint(result))
+
+	return code, err == nil
+}
+
+// typeNameOf returns the name of the type of an expression, as an annotation names it in the file of the expression.
+func typeNameOf(value handlerExpr) (string, bool) {
+	typ := typeOf(value.expr, 0)
+	if typ == nil {
+		return "", false
+	}
+
+	return typeName(typ)
+}
+
+// typeOf returns the type of an expression, declared by a composite literal or by a variable or a parameter.
+func typeOf(expr ast.Expr, depth int) ast.Expr {
+	if depth > maxHandlerDepth*4 {
+		return nil
+	}
+
+	switch x := expr.(type) {
+	case *ast.ParenExpr:
+		return typeOf(x.X, depth+1)
+	case *ast.UnaryExpr:
+		if x.Op == token.AND {
+			return typeOf(x.X, depth+1)
+		}
+	case *ast.StarExpr:
+		return typeOf(x.X, depth+1)
+	case *ast.CompositeLit:
+		return x.Type
+	case *ast.BasicLit:
+		switch x.Kind {
+		case token.STRING:
+			return ast.NewIdent(STRING)
+		case token.INT:
+			return ast.NewIdent(INTEGER)
+		case token.FLOAT:
+			return ast.NewIdent(NUMBER)
+		}
+	case *ast.CallExpr:
+		if fun, ok := x.Fun.(*ast.Ident); ok && (fun.Name == "new" || fun.Name == "make") && len(x.Args) > 0 {
+			return x.Args[0]
+		}
+	case *ast.Ident:
+		if x.Obj == nil {
+			return nil
+		}
+
+		switch decl := x.Obj.Decl.(type) {
+		case *ast.Field:
+			return decl.Type
+		case *ast.ValueSpec:
+			if decl.Type != nil {
+				return decl.Type
+			}
+			for i, name := range decl.Names {
+				if name.Obj == x.Obj && i < len(decl.Values) && len(decl.Values) == len(decl.Names) {
+					return typeOf(decl.Values[i], depth+1)
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range decl.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && ident.Obj == x.Obj && len(decl.Rhs) == len(decl.Lhs) {
+					return typeOf(decl.Rhs[i], depth+1)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// typeName returns the name of a type as an annotation names it, e.g. []model.Account for []*model.Account.
+func typeName(typ ast.Expr) (string, bool) {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		return t.Name, true
+	case *ast.SelectorExpr, *ast.IndexExpr, *ast.IndexListExpr:
+		return types.ExprString(t), true
+	case *ast.StarExpr:
+		return typeName(t.X)
+	case *ast.ArrayType:
+		elem, ok := typeName(t.Elt)
+
+		return "[]" + elem, ok
+	case *ast.MapType:
+		key, ok := typeName(t.Key)
+		if !ok {
+			return "", false
+		}
+		value, ok := typeName(t.Value)
+
+		return "map[" + key + "]" + value, ok
+	case *ast.InterfaceType:
+		return INTERFACE, true
+	}
+
+	return "", false
+}
+
+// bindingName names a param after the variable a request is bound to.
+func bindingName(expr ast.Expr) string {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+
+	return "request"
+}
+
+// isPackageName whether an expression is the name of an imported package, as http in http.Error.
+func isPackageName(file *ast.File, expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && ident.Obj == nil && importPath(file, ident.Name) != ""
+}
+
+// hasBody whether the routes of an operation have a request body, the routes are unknown before they are inferred.
+func hasBody(routes []RouteProperties) bool {
+	for _, route := range routes {
+		switch route.HTTPMethod {
+		case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions, http.MethodTrace:
+		default:
+			return true
+		}
+	}
+
+	return len(routes) == 0
+}
+
+// hasParamIn whether an operation has a param in a location.
+func hasParamIn(operation *Operation, in string) bool {
+	for _, param := range operation.Parameters {
+		if param.In == in {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,265 @@
+package swag
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferHandlerTypes(t *testing.T) {
+	t.Parallel()
+
+	httputilSrc := `
+package httputil
+
+import "github.com/gin-gonic/gin"
+
+type HTTPError struct {
+	Code    int    ` + "`json:\"code\"`" + `
+	Message string ` + "`json:\"message\"`" + `
+}
+
+func NewError(ctx *gin.Context, status int, err error) {
+	er := HTTPError{Code: status, Message: err.Error()}
+	ctx.JSON(status, er)
+}
+`
+	apiSrc := `
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"httputil"
+)
+
+type Account struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type AddAccount struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type Filter struct {
+	Name string ` + "`form:\"name\"`" + `
+}
+
+// AddAccount godoc
+// @Summary Add an account
+// @Router /accounts [post]
+func AddAccount(c *gin.Context) {
+	var req AddAccount
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.NewError(c, http.StatusBadRequest, err)
+		return
+	}
+	account := &Account{Name: req.Name}
+	c.JSON(http.StatusCreated, account)
+}
+
+// ListAccounts godoc
+// @Summary List accounts
+// @Success 200 {array} Account "the accounts"
+// @Router /accounts [get]
+func ListAccounts(c *gin.Context) {
+	q := new(Filter)
+	if err := c.Bind(q); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, []Account{})
+}
+
+// DeleteAccount godoc
+// @Summary Delete an account
+// @Router /accounts/{id} [delete]
+func DeleteAccount(c *gin.Context) {
+	c.Status(http.StatusNotFound).JSON(httputil.HTTPError{})
+	c.NoContent(204)
+}
+`
+	p := New(SetInferHandlerTypes(true))
+	assert.NoError(t, p.packages.ParseFile("httputil", "httputil/httputil.go", httputilSrc, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", apiSrc, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	expected := `{
+   "/accounts": {
+      "get": {
+         "summary": "List accounts",
+         "parameters": [
+            {
+               "type": "string",
+               "name": "name",
+               "in": "query"
+            }
+         ],
+         "responses": {
+            "200": {
+               "description": "the accounts",
+               "schema": {
+                  "type": "array",
+                  "items": {
+                     "$ref": "#/definitions/api.Account"
+                  }
+               }
+            },
+            "400": {
+               "description": "Bad Request"
+            }
+         }
+      },
+      "post": {
+         "summary": "Add an account",
+         "parameters": [
+            {
+               "description": "req",
+               "name": "req",
+               "in": "body",
+               "required": true,
+               "schema": {
+                  "$ref": "#/definitions/api.AddAccount"
+               }
+            }
+         ],
+         "responses": {
+            "201": {
+               "description": "Created",
+               "schema": {
+                  "$ref": "#/definitions/api.Account"
+               }
+            },
+            "400": {
+               "description": "Bad Request",
+               "schema": {
+                  "$ref": "#/definitions/httputil.HTTPError"
+               }
+            }
+         }
+      }
+   },
+   "/accounts/{id}": {
+      "delete": {
+         "summary": "Delete an account",
+         "parameters": [
+            {
+               "type": "string",
+               "name": "id",
+               "in": "path",
+               "required": true
+            }
+         ],
+         "responses": {
+            "204": {
+               "description": "No Content"
+            },
+            "404": {
+               "description": "Not Found",
+               "schema": {
+                  "$ref": "#/definitions/httputil.HTTPError"
+               }
+            }
+         }
+      }
+   }
+}`
+	b, err := json.MarshalIndent(p.swagger.Paths.Paths, "", "   ")
+	assert.NoError(t, err)
+	assert.JSONEq(t, expected, string(b))
+}
+
+func TestInferHandlerTypesDisabled(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+import "github.com/gin-gonic/gin"
+
+type Account struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+// @Summary Show an account
+// @Router /accounts [get]
+func ShowAccount(c *gin.Context) {
+	c.JSON(200, Account{})
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	assert.Empty(t, p.swagger.Paths.Paths["/accounts"].Get.Responses.StatusCodeResponses)
+}
+
+func TestInferHandlerTypesOfContextOnly(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Account struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+type report struct{}
+
+func (report) JSON(data interface{}) error {
+	return nil
+}
+
+// @Summary Show an account
+// @Router /accounts/{id} [get]
+func ShowAccount(c *fiber.Ctx) error {
+	var sb strings.Builder
+	sb.WriteString("account")
+	_ = sb.String()
+
+	var r report
+	_ = r.JSON(Account{})
+
+	ctx := c
+	return ctx.Status(http.StatusCreated).JSON(Account{})
+}
+`
+	p := New(SetInferHandlerTypes(true))
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	// String() of the builder and JSON of the report are not responses
+	responses := p.swagger.Paths.Paths["/accounts/{id}"].Get.Responses.StatusCodeResponses
+	assert.Len(t, responses, 1)
+	if assert.Contains(t, responses, http.StatusCreated) {
+		assert.Equal(t, "#/definitions/api.Account", responses[http.StatusCreated].Schema.Ref.String())
+	}
+}
+
+func TestHTTPStatusCodes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 200, httpStatusCodes["StatusOK"])
+	assert.Equal(t, 404, httpStatusCodes["StatusNotFound"])
+	assert.Equal(t, 418, httpStatusCodes["StatusTeapot"])
+	assert.Equal(t, 422, httpStatusCodes["StatusUnprocessableEntity"])
+	assert.Equal(t, 500, httpStatusCodes["StatusInternalServerError"])
+}
@@ -0,0 +1,360 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-openapi/spec"
+)
+
+// Definition naming strategies.
+const (
+	// DefinitionNameFull names a definition after the full import path of its package, e.g. github_com_org_svc_model.User.
+	DefinitionNameFull = "full"
+	// DefinitionNameShort names a definition after its type only, e.g. User, colliding names get a numeric suffix.
+	DefinitionNameShort = "short"
+	// DefinitionNamePath names a definition after the last N segments of its package path, e.g. path:2 gives svc_model.User.
+	DefinitionNamePath = "path"
+)
+
+// DefinitionName the parts of a type which a definition is named after.
+type DefinitionName struct {
+	// PkgPath the import path of the package of the type
+	PkgPath string
+	// Package the name of the package of the type
+	Package string
+	// Name the name of the type without its type arguments, types declared in a function are prefixed with its name
+	Name string
+	// TypeArgs the names of the type arguments of a generic instantiation, e.g. User for Page[model.User]
+	TypeArgs []string
+	// Default the name of the definition without a naming strategy
+	Default string
+}
+
+// TypeName the name of the type with its type arguments, e.g. PageOfUser for Page[model.User]
+// or PairOfUserAndOrder for Pair[model.User, model.Order].
+func (name DefinitionName) TypeName() string {
+	if len(name.TypeArgs) == 0 {
+		return name.Name
+	}
+
+	return name.Name + "Of" + strings.Join(name.TypeArgs, "And")
+}
+
+// DefinitionNameFunc names the definition of a type, an empty name keeps its default name.
+type DefinitionNameFunc func(name DefinitionName) (string, error)
+
+// DefinitionNamer returns the DefinitionNameFunc of a naming strategy:
+// full, short, path:N or a Go template executed with a DefinitionName, e.g. {{.Package}}{{.TypeName}}.
+// An empty strategy keeps the default names.
+func DefinitionNamer(strategy string) (DefinitionNameFunc, error) {
+	switch {
+	case strategy == "":
+		return nil, nil
+	case strategy == DefinitionNameFull:
+		return func(name DefinitionName) (string, error) {
+			return fullTypeName(pkgPathIdentifier(name.PkgPath), name.TypeName()), nil
+		}, nil
+	case strategy == DefinitionNameShort:
+		return func(name DefinitionName) (string, error) {
+			return name.TypeName(), nil
+		}, nil
+	case strings.HasPrefix(strategy, DefinitionNamePath+":"):
+		segments, err := strconv.Atoi(strings.TrimPrefix(strategy, DefinitionNamePath+":"))
+		if err != nil || segments < 1 {
+			return nil, fmt.Errorf("invalid definition naming strategy %s, expected path:N with N > 0", strategy)
+		}
+
+		return func(name DefinitionName) (string, error) {
+			parts := strings.Split(name.PkgPath, "/")
+			if len(parts) > segments {
+				parts = parts[len(parts)-segments:]
+			}
+
+			return fullTypeName(pkgPathIdentifier(strings.Join(parts, "/")), name.TypeName()), nil
+		}, nil
+	case strings.Contains(strategy, "{{"):
+		tmpl, err := template.New("definitionName").Parse(strategy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid definition naming template: %w", err)
+		}
+
+		return func(name DefinitionName) (string, error) {
+			var sb strings.Builder
+			if err := tmpl.Execute(&sb, name); err != nil {
+				return "", err
+			}
+
+			return strings.TrimSpace(sb.String()), nil
+		}, nil
+	}
+
+	return nil, fmt.Errorf("not supported %s definition naming strategy", strategy)
+}
+
+// pkgPathIdentifier replaces the separators of a package path, as TypeSpecDef.TypeName does for not unique types.
+func pkgPathIdentifier(pkgPath string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\\' || r == '/' || r == '.' {
+			return '_'
+		}
+		return r
+	}, pkgPath)
+}
+
+// typeArgName names a type argument of a generic instantiation, e.g. UserArray for []model.User
+// or PageOfUser for model.Page[model.User].
+func typeArgName(typeArg string) string {
+	switch {
+	case strings.HasPrefix(typeArg, "*"):
+		return typeArgName(typeArg[1:])
+	case strings.HasPrefix(typeArg, "[]"):
+		return typeArgName(typeArg[2:]) + "Array"
+	case strings.HasPrefix(typeArg, "map["):
+		if parts := strings.SplitN(typeArg[4:], "]", 2); len(parts) == 2 {
+			return typeArgName(parts[1]) + "Map"
+		}
+	}
+
+	name := DefinitionName{Name: typeArg}
+	if strings.HasSuffix(typeArg, "]") {
+		var typeArgs []string
+		name.Name, typeArgs = splitGenericsTypeName(typeArg)
+		for _, arg := range typeArgs {
+			name.TypeArgs = append(name.TypeArgs, typeArgName(arg))
+		}
+	}
+
+	name.Name = name.Name[strings.LastIndexByte(name.Name, '.')+1:]
+	if r, size := utf8.DecodeRuneInString(name.Name); size > 0 {
+		name.Name = string(unicode.ToUpper(r)) + name.Name[size:]
+	}
+
+	return name.TypeName()
+}
+
+// definitionName returns the parts of the type which its definition is named after.
+func (t *TypeSpecDef) definitionName() DefinitionName {
+	base := t
+	if t.genericBase != nil {
+		base = t.genericBase
+	}
+
+	name := DefinitionName{
+		PkgPath: base.PkgPath,
+		Name:    base.Name(),
+		Default: t.SchemaName,
+	}
+	if base.File != nil {
+		name.Package = base.File.Name.Name
+	}
+	if parentFun, ok := base.ParentSpec.(*ast.FuncDecl); ok && parentFun != nil {
+		name.Name = parentFun.Name.Name + "_" + name.Name
+	}
+	for _, typeArg := range t.typeArgs {
+		name.TypeArgs = append(name.TypeArgs, typeArgName(typeArg))
+	}
+
+	return name
+}
+
+// definitionName names the definition of a type with the naming strategy of the parser,
+// a name already given to another type gets a numeric suffix, see renameDefinitions.
+func (parser *Parser) definitionName(typeSpecDef *TypeSpecDef) (string, error) {
+	if name, ok := parser.definitionNames[typeSpecDef]; ok {
+		return name, nil
+	}
+
+	info := typeSpecDef.definitionName()
+	name, err := parser.definitionNameFunc(info)
+	if err != nil {
+		err = fmt.Errorf("cannot name the definition of %s: %w", typeSpecDef.FullPath(), err)
+		if parser.Strict {
+			return "", err
+		}
+		parser.debug.Printf("warning: %s, using %s", err, info.Default)
+	}
+	if name == "" {
+		name = info.Default
+	}
+
+	unique := name
+	for i := 2; ; i++ {
+		owner, ok := parser.definitionOwners[unique]
+		if !ok || owner == typeSpecDef {
+			break
+		}
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+
+	parser.definitionNames[typeSpecDef] = unique
+	parser.definitionOwners[unique] = typeSpecDef
+	parser.definitionBaseNames[typeSpecDef] = name
+
+	return unique, nil
+}
+
+// renameDefinitions gives the names of the definitions which were given the same name by the naming strategy
+// to their types in the order of their import paths, so that the numeric suffixes do not depend
+// on the order the types were parsed in, and updates the references to the renamed definitions.
+func (parser *Parser) renameDefinitions() {
+	collisions := make(map[string][]*TypeSpecDef)
+	for typeSpecDef, name := range parser.definitionBaseNames {
+		collisions[name] = append(collisions[name], typeSpecDef)
+	}
+
+	renames := make(map[string]string)
+	for name, typeSpecDefs := range collisions {
+		if len(typeSpecDefs) < 2 {
+			continue
+		}
+
+		names := make([]string, 0, len(typeSpecDefs))
+		for _, typeSpecDef := range typeSpecDefs {
+			names = append(names, parser.definitionNames[typeSpecDef])
+		}
+		sort.Slice(names, func(i, j int) bool {
+			return definitionSuffix(names[i], name) < definitionSuffix(names[j], name)
+		})
+		sort.Slice(typeSpecDefs, func(i, j int) bool {
+			if typeSpecDefs[i].FullPath() != typeSpecDefs[j].FullPath() {
+				return typeSpecDefs[i].FullPath() < typeSpecDefs[j].FullPath()
+			}
+
+			return typeSpecDefs[i].TypeName() < typeSpecDefs[j].TypeName()
+		})
+
+		for i, typeSpecDef := range typeSpecDefs {
+			current := parser.definitionNames[typeSpecDef]
+			if current == names[i] {
+				continue
+			}
+
+			renames[current] = names[i]
+			parser.definitionNames[typeSpecDef] = names[i]
+			parser.definitionOwners[names[i]] = typeSpecDef
+			typeSpecDef.SchemaName = names[i]
+			if schema, ok := parser.parsedSchemas[typeSpecDef]; ok {
+				schema.Name = names[i]
+			}
+			if schema, ok := parser.outputSchemas[typeSpecDef]; ok {
+				schema.Name = names[i]
+			}
+		}
+		parser.debug.Printf("Definition name %s is given to %d types, named %s in the order of their import paths",
+			name, len(names), strings.Join(names, ", "))
+	}
+
+	if len(renames) == 0 {
+		return
+	}
+
+	definitions := make(spec.Definitions, len(parser.swagger.Definitions))
+	for name, schema := range parser.swagger.Definitions {
+		if renamed, ok := renames[name]; ok {
+			name = renamed
+		}
+		renameSchemaRefs(&schema, renames)
+		definitions[name] = schema
+	}
+	parser.swagger.Definitions = definitions
+
+	for _, param := range parser.swagger.Parameters {
+		renameSchemaRefs(param.Schema, renames)
+	}
+	for _, response := range parser.swagger.Responses {
+		renameSchemaRefs(response.Schema, renames)
+	}
+	if parser.swagger.Paths == nil {
+		return
+	}
+	for _, item := range parser.swagger.Paths.Paths {
+		for i := range item.Parameters {
+			renameSchemaRefs(item.Parameters[i].Schema, renames)
+		}
+		for method := range allMethod {
+			if op := *refRouteMethodOp(&item, method); op != nil {
+				renameOperationRefs(op, renames)
+			}
+		}
+	}
+}
+
+// definitionSuffix returns the numeric suffix renameDefinitions gave a definition name, 1 for no suffix.
+func definitionSuffix(name, base string) int {
+	suffix, err := strconv.Atoi(strings.TrimPrefix(name, base+"_"))
+	if err != nil {
+		return 1
+	}
+
+	return suffix
+}
+
+// renameOperationRefs updates the references of the schemas of the parameters and responses of an operation.
+func renameOperationRefs(op *spec.Operation, renames map[string]string) {
+	for i := range op.Parameters {
+		renameSchemaRefs(op.Parameters[i].Schema, renames)
+	}
+	if op.Responses == nil {
+		return
+	}
+	if op.Responses.Default != nil {
+		renameSchemaRefs(op.Responses.Default.Schema, renames)
+	}
+	for _, response := range op.Responses.StatusCodeResponses {
+		renameSchemaRefs(response.Schema, renames)
+	}
+}
+
+// renameSchemaRefs updates the references of a schema and its subschemas to the renamed definitions.
+func renameSchemaRefs(schema *spec.Schema, renames map[string]string) {
+	if schema == nil {
+		return
+	}
+
+	if ref := schema.Ref.String(); strings.HasPrefix(ref, "#/definitions/") {
+		if renamed, ok := renames[strings.TrimPrefix(ref, "#/definitions/")]; ok {
+			schema.Ref = spec.MustCreateRef("#/definitions/" + renamed)
+		}
+	}
+
+	if schema.Items != nil {
+		renameSchemaRefs(schema.Items.Schema, renames)
+		for i := range schema.Items.Schemas {
+			renameSchemaRefs(&schema.Items.Schemas[i], renames)
+		}
+	}
+	for _, schemas := range [][]spec.Schema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for i := range schemas {
+			renameSchemaRefs(&schemas[i], renames)
+		}
+	}
+	renameSchemaRefs(schema.Not, renames)
+	for name, property := range schema.Properties {
+		renameSchemaRefs(&property, renames)
+		schema.Properties[name] = property
+	}
+	if schema.AdditionalProperties != nil {
+		renameSchemaRefs(schema.AdditionalProperties.Schema, renames)
+	}
+	if keySchema, ok := schema.Extensions[mapKeyTypeExtension].(*spec.Schema); ok {
+		renameSchemaRefs(keySchema, renames)
+	}
+	if discriminator, ok := schema.ExtraProps["discriminator"].(map[string]interface{}); ok {
+		if mapping, ok := discriminator["mapping"].(map[string]interface{}); ok {
+			for value, ref := range mapping {
+				if ref, ok := ref.(string); ok && strings.HasPrefix(ref, "#/definitions/") {
+					if renamed, ok := renames[strings.TrimPrefix(ref, "#/definitions/")]; ok {
+						mapping[value] = "#/definitions/" + renamed
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package swag
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefinitionNamer(t *testing.T) {
+	t.Parallel()
+
+	name := DefinitionName{
+		PkgPath:  "github.com/org/svc/internal/model",
+		Package:  "model",
+		Name:     "Page",
+		TypeArgs: []string{"User"},
+		Default:  "model.Page-model_User",
+	}
+
+	for strategy, expected := range map[string]string{
+		DefinitionNameFull:  "github_com_org_svc_internal_model.PageOfUser",
+		DefinitionNameShort: "PageOfUser",
+		"path:2":            "internal_model.PageOfUser",
+		"path:9":            "github_com_org_svc_internal_model.PageOfUser",
+		"{{.Package | printf \"%.3s\"}}{{.TypeName}}": "modPageOfUser",
+	} {
+		namer, err := DefinitionNamer(strategy)
+		assert.NoError(t, err)
+		actual, err := namer(name)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, strategy)
+	}
+
+	namer, err := DefinitionNamer("")
+	assert.NoError(t, err)
+	assert.Nil(t, namer)
+
+	for _, strategy := range []string{"long", "path:0", "path:x", "{{.Name"} {
+		_, err = DefinitionNamer(strategy)
+		assert.Error(t, err, strategy)
+	}
+
+	namer, err = DefinitionNamer("{{.Owner}}{{.TypeName}}")
+	assert.NoError(t, err)
+	_, err = namer(name)
+	assert.Error(t, err)
+}
+
+func TestTypeArgName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "User", typeArgName("model.User"))
+	assert.Equal(t, "String", typeArgName("*string"))
+	assert.Equal(t, "UserArray", typeArgName("[]model.User"))
+	assert.Equal(t, "UserMap", typeArgName("map[string]*model.User"))
+	assert.Equal(t, "PairOfUserAndInt", typeArgName("model.Pair[model.User,int]"))
+}
+
+func TestParseDefinitionNaming(t *testing.T) {
+	t.Parallel()
+
+	users := `
+package users
+
+type User struct {
+	Name string
+}
+`
+	admins := `
+package admins
+
+type User struct {
+	Role string
+}
+`
+	api := `
+package api
+
+import (
+	"example.com/svc/admins"
+	"example.com/svc/users"
+)
+
+type Page[T any] struct {
+	Items []T
+}
+
+// @Success 200 {object} Page[users.User]
+// @Success 201 {object} admins.User
+// @Success 202 {object} Page[[]admins.User]
+// @Router /users [get]
+func Get() {
+}
+`
+	namer, err := DefinitionNamer(DefinitionNameShort)
+	assert.NoError(t, err)
+
+	p := New(SetDefinitionNameFunc(namer))
+	assert.NoError(t, p.packages.ParseFile("example.com/svc/users", "users/users.go", users, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("example.com/svc/admins", "admins/admins.go", admins, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("example.com/svc/api", "api/api.go", api, ParseAll))
+
+	p.parsedSchemas, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+	p.renameDefinitions()
+
+	var names []string
+	for name := range p.swagger.Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"PageOfUser", "PageOfUserArray", "User", "User_2"}, names)
+
+	responses := p.swagger.Paths.Paths["/users"].Get.Responses.StatusCodeResponses
+	assert.Equal(t, "#/definitions/PageOfUser", responses[200].Schema.Ref.String())
+	assert.Equal(t, "#/definitions/PageOfUserArray", responses[202].Schema.Ref.String())
+
+	// the suffixes follow the import paths of the types rather than the order they are parsed in
+	assert.Equal(t, "#/definitions/User", responses[201].Schema.Ref.String())
+	assert.Contains(t, p.swagger.Definitions["User"].Properties, "role")
+	assert.Contains(t, p.swagger.Definitions["User_2"].Properties, "name")
+	assert.Equal(t, "#/definitions/User_2", p.swagger.Definitions["PageOfUser"].Properties["items"].Items.Schema.Ref.String())
+	assert.Equal(t, "#/definitions/User", p.swagger.Definitions["PageOfUserArray"].Properties["items"].Items.Schema.Items.Schema.Ref.String())
+}
+
+func TestParseDefinitionNamingTemplateError(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type User struct {
+	Name string
+}
+
+// @Success 200 {object} User
+// @Router /users [get]
+func Get() {
+}
+`
+	namer, err := DefinitionNamer("{{.Owner}}{{.TypeName}}")
+	assert.NoError(t, err)
+
+	logger := &testLogger{}
+	p := New(SetDefinitionNameFunc(namer), SetDebugger(logger))
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+	assert.Contains(t, p.swagger.Definitions, "api.User")
+	assert.Contains(t, strings.Join(logger.Messages, "\n"), "warning: cannot name the definition of api.User")
+
+	p = New(SetDefinitionNameFunc(namer), SetStrict(true))
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	assert.ErrorContains(t, p.packages.RangeFiles(p.ParseRouterAPIInfo), "cannot name the definition of api.User")
+}
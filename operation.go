@@ -32,6 +32,22 @@ type Operation struct {
 	spec.Operation
 	RouterProperties []RouteProperties
 	State            string
+
+	// handler the function the operation is the doc comment of
+	handler routeHandler
+
+	// since and until the range of versions of the API the operation is in, declared by @Since and @Until
+	since string
+	until string
+
+	// deprecation the lifecycle declared by @Deprecated, nil for none
+	deprecation *deprecation
+
+	// noDefaultFailures the statuses of the default failures the operation opts out of, all of them for an empty status
+	noDefaultFailures map[string]bool
+
+	// usedFragments the fragments being expanded by @Use, to detect a fragment using itself
+	usedFragments []string
 }
 
 var mimeTypeAliases = map[string]string{
@@ -174,7 +190,13 @@ func (operation *Operation) ParseComment(comment string, astFile *ast.File) erro
 	case securityAttr:
 		return operation.ParseSecurityComment(lineRemainder)
 	case deprecatedAttr:
-		operation.Deprecate()
+		return operation.ParseDeprecatedComment(lineRemainder)
+	case sinceAttr, untilAttr:
+		return operation.ParseVersionRangeComment(lowerAttribute, lineRemainder)
+	case noDefaultFailuresAttr:
+		operation.ParseNoDefaultFailuresComment(lineRemainder)
+	case useAttr:
+		return operation.ParseUseComment(lineRemainder, astFile)
 	case xCodeSamplesAttr:
 		return operation.ParseCodeSample(attribute, commentLine, lineRemainder)
 	default:
@@ -320,6 +342,7 @@ func (operation *Operation) ParseParamComment(commentLine string, astFile *ast.F
 				return err
 			}
 
+			schema = operation.parser.flattenAllOf(schema)
 			if len(schema.Properties) == 0 {
 				return nil
 			}
@@ -360,24 +383,43 @@ func (operation *Operation) ParseParamComment(commentLine string, astFile *ast.F
 					if len(itemSchema.Type) == 0 {
 						continue
 					}
-					if !IsSimplePrimitiveType(itemSchema.Type[0]) {
-						continue
-					}
 					collectionFormat := operation.parser.collectionFormatInQuery
 					if cfv, ok := prop.Extensions.GetString(collectionFormatTag); ok {
 						collectionFormat = cfv
 					}
-					param = createParameter(paramType, prop.Description, name, prop.Type[0], itemSchema.Type[0], format, findInSlice(schema.Required, item.Name), itemSchema.Enum, collectionFormat)
+					switch {
+					case paramType == "formData" && IsBinarySchema(itemSchema):
+						param = createParameter(paramType, prop.Description, name, prop.Type[0], "file", "", findInSlice(schema.Required, item.Name), nil, collectionFormat)
+					case IsSimplePrimitiveType(itemSchema.Type[0]):
+						param = createParameter(paramType, prop.Description, name, prop.Type[0], itemSchema.Type[0], format, findInSlice(schema.Required, item.Name), itemSchema.Enum, collectionFormat)
+					case paramType == "formData":
+						param = createFormDataPart(prop.Description, name, item.Schema, findInSlice(schema.Required, item.Name))
+					default:
+						continue
+					}
 
+				case paramType == "formData" && IsBinarySchema(prop):
+					param = createParameter(paramType, prop.Description, name, PRIMITIVE, "file", "", findInSlice(schema.Required, item.Name), nil, operation.parser.collectionFormatInQuery)
 				case IsSimplePrimitiveType(prop.Type[0]):
 					param = createParameter(paramType, prop.Description, name, PRIMITIVE, prop.Type[0], format, findInSlice(schema.Required, item.Name), nil, operation.parser.collectionFormatInQuery)
+				case paramType == "formData":
+					param = createFormDataPart(prop.Description, name, item.Schema, findInSlice(schema.Required, item.Name))
 				default:
 					operation.parser.debug.Printf("skip field [%s] in %s is not supported type for %s", name, refType, paramType)
 					continue
 				}
 
+				if param.Schema != nil {
+					// a JSON part keeps its schema
+					operation.Operation.Parameters = append(operation.Operation.Parameters, param)
+
+					continue
+				}
+
 				param.Nullable = prop.Nullable
-				param.Format = prop.Format
+				if param.Type != "file" {
+					param.Format = prop.Format
+				}
 				param.Default = prop.Default
 				param.Example = prop.Example
 				param.Extensions = prop.Extensions
@@ -419,6 +461,11 @@ func (operation *Operation) ParseParamComment(commentLine string, astFile *ast.F
 		return err
 	}
 
+	err = operation.parseParamValueReferences(commentLine, astFile, &param)
+	if err != nil {
+		return err
+	}
+
 	operation.Operation.Parameters = append(operation.Operation.Parameters, param)
 
 	return nil
@@ -435,6 +482,7 @@ const (
 	exampleTag          = "example"
 	schemaExampleTag    = "schemaExample"
 	formatTag           = "format"
+	patternTag          = "pattern"
 	titleTag            = "title"
 	validateTag         = "validate"
 	minimumTag          = "minimum"
@@ -732,7 +780,8 @@ func parseMimeTypeList(mimeTypeList string, typeList *[]string, format string) e
 	return nil
 }
 
-var routerPattern = regexp.MustCompile(`^(/[\w./\-{}\(\)+:$~]*)[[:blank:]]+\[(\w+)]`)
+// routerPattern matches the path and the method of a route, the path may constrain its parameters by regular expressions, e.g. /items/{id:[0-9]+}.
+var routerPattern = regexp.MustCompile(`^(/[\w./\-{}\(\)+:$~\[\]|\\*?^,]*)[[:blank:]]+\[(\w+)]`)
 
 // ParseRouterComment parses comment for given `router` comment string.
 func (operation *Operation) ParseRouterComment(commentLine string, deprecated bool) error {
@@ -881,23 +930,30 @@ func parseObjectSchema(parser *Parser, refType string, astFile *ast.File) (*spec
 
 		return spec.ArrayProperty(schema), nil
 	case strings.HasPrefix(refType, "map["):
-		// ignore key type
 		idx := strings.Index(refType, "]")
 		if idx < 0 {
 			return nil, fmt.Errorf("invalid type: %s", refType)
 		}
 
+		keyType := refType[len("map["):idx]
 		refType = refType[idx+1:]
-		if refType == INTERFACE || refType == ANY {
-			return spec.MapProperty(nil), nil
+
+		schema := spec.MapProperty(nil)
+		if refType != INTERFACE && refType != ANY {
+			valueSchema, err := parseObjectSchema(parser, refType, astFile)
+			if err != nil {
+				return nil, err
+			}
+			schema = spec.MapProperty(valueSchema)
 		}
 
-		schema, err := parseObjectSchema(parser, refType, astFile)
-		if err != nil {
-			return nil, err
+		if parser != nil {
+			if keySchema := parser.parseMapKeySchema(astFile, keyType); keySchema != nil {
+				schema.AddExtension(mapKeyTypeExtension, keySchema)
+			}
 		}
 
-		return spec.MapProperty(schema), nil
+		return schema, nil
 	case strings.Contains(refType, "{"):
 		return parseCombinedObjectSchema(parser, refType, astFile)
 	default:
@@ -1011,6 +1067,17 @@ func (operation *Operation) parseAPIObjectSchema(commentLine, schemaType, refTyp
 
 // ParseResponseComment parses comment for given `response` comment string.
 func (operation *Operation) ParseResponseComment(commentLine string, astFile *ast.File) error {
+	var example interface{}
+	if exampleMatches := responseExampleRegexp.FindStringSubmatch(commentLine); len(exampleMatches) == 2 {
+		value, err := operation.parser.evaluateValueReference(astFile, exampleMatches[1])
+		if err != nil {
+			return err
+		}
+
+		example = value
+		commentLine = strings.Replace(commentLine, exampleMatches[0], "", 1)
+	}
+
 	matches := responsePattern.FindStringSubmatch(commentLine)
 	if len(matches) != 5 {
 		err := operation.ParseEmptyResponseComment(commentLine)
@@ -1030,7 +1097,10 @@ func (operation *Operation) ParseResponseComment(commentLine string, astFile *as
 
 	for _, codeStr := range strings.Split(matches[1], ",") {
 		if strings.EqualFold(codeStr, defaultTag) {
-			operation.DefaultResponse().WithSchema(schema).WithDescription(description)
+			resp := operation.DefaultResponse().WithSchema(schema).WithDescription(description)
+			if example != nil {
+				resp.AddExample(mimeTypeAliases["json"], example)
+			}
 
 			continue
 		}
@@ -1044,6 +1114,9 @@ func (operation *Operation) ParseResponseComment(commentLine string, astFile *as
 		if description == "" {
 			resp.WithDescription(http.StatusText(code))
 		}
+		if example != nil {
+			resp.AddExample(mimeTypeAliases["json"], example)
+		}
 
 		operation.AddResponse(code, resp)
 	}
@@ -1241,6 +1314,34 @@ func createParameter(paramType, description, paramName, objectType, schemaType s
 	return result
 }
 
+// createFormDataPart creates a formData parameter of a field which is not a simple value, such as a struct,
+// which a multipart form sends as a JSON part.
+func createFormDataPart(description, paramName string, schema spec.Schema, required bool) spec.Parameter {
+	// the names of the field in the other parameter types are not a part of its schema
+	extensions := spec.Extensions{}
+	for key, value := range schema.Extensions {
+		switch key {
+		case "formdata", "header", "path", "collectionformat":
+		default:
+			extensions[key] = value
+		}
+	}
+	schema.Extensions = nil
+	if len(extensions) > 0 {
+		schema.Extensions = extensions
+	}
+
+	return spec.Parameter{
+		ParamProps: spec.ParamProps{
+			Name:        paramName,
+			Description: description,
+			Required:    required,
+			In:          "formData",
+			Schema:      &schema,
+		},
+	}
+}
+
 func getCodeExampleForSummary(summaryName string, dirPath string) ([]byte, error) {
 	dirEntries, err := os.ReadDir(dirPath)
 	if err != nil {
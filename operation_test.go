@@ -2657,3 +2657,116 @@ func TestParseDeprecatedRouter(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, b)
 }
+
+func TestParseParamCommentByFormDataStructWithFiles(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+import "mime/multipart"
+
+type Meta struct {
+	Title string ` + "`json:\"title\"`" + `
+}
+
+type Upload struct {
+	Name        string                  ` + "`form:\"name\" binding:\"required\"`" + `
+	File        *multipart.FileHeader   ` + "`form:\"file\" binding:\"required\"`" + `
+	Attachments []*multipart.FileHeader ` + "`form:\"attachments\"`" + `
+	Meta        Meta                    ` + "`form:\"meta\"`" + `
+}
+
+// @Param req formData Upload true "upload"
+// @Router /uploads [post]
+func Post() {
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	expected := `[
+   {
+      "type": "array",
+      "items": {
+         "type": "file"
+      },
+      "name": "attachments",
+      "in": "formData"
+   },
+   {
+      "type": "file",
+      "name": "file",
+      "in": "formData",
+      "required": true
+   },
+   {
+      "name": "meta",
+      "in": "formData",
+      "schema": {
+         "$ref": "#/definitions/api.Meta"
+      }
+   },
+   {
+      "type": "string",
+      "name": "name",
+      "in": "formData",
+      "required": true
+   }
+]`
+	b, _ := json.MarshalIndent(p.swagger.Paths.Paths["/uploads"].Post.Parameters, "", "   ")
+	assert.Equal(t, expected, string(b))
+}
+
+func TestParseParamCommentByFormDataStructWithAliasedFiles(t *testing.T) {
+	t.Parallel()
+
+	upload := `
+package multipart
+
+// FileHeader is not the one of mime/multipart
+type FileHeader struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`
+	src := `
+package api
+
+import (
+	mp "mime/multipart"
+
+	"example.com/upload/multipart"
+)
+
+type Upload struct {
+	File   *mp.FileHeader        ` + "`form:\"file\"`" + `
+	Header multipart.FileHeader ` + "`form:\"header\"`" + `
+}
+
+// @Param req formData Upload true "upload"
+// @Router /uploads [post]
+func Post() {
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("example.com/upload/multipart", "multipart/multipart.go", upload, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	params := p.swagger.Paths.Paths["/uploads"].Post.Parameters
+	if assert.Len(t, params, 2) {
+		assert.Equal(t, "file", params[0].Name)
+		assert.Equal(t, "file", params[0].Type)
+		assert.Equal(t, "header", params[1].Name)
+		assert.Equal(t, "#/definitions/multipart.FileHeader", params[1].Schema.Ref.String())
+	}
+}
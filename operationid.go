@@ -0,0 +1,186 @@
+package swag
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-openapi/spec"
+)
+
+// OperationId naming strategies.
+const (
+	// OperationIDFunc names an operation after the function of its handler, e.g. ShowAccount.
+	OperationIDFunc = "func"
+	// OperationIDReceiver names an operation after the receiver type and the method of its handler, e.g. Controller.ShowAccount.
+	OperationIDReceiver = "receiver"
+	// OperationIDPath names an operation after its method and path, e.g. getAccountsById for GET /accounts/{id}.
+	OperationIDPath = "path"
+)
+
+// OperationIDName the parts of an operation which its operationId is named after.
+type OperationIDName struct {
+	// PkgPath the import path of the package of the handler
+	PkgPath string
+	// Receiver the name of the receiver type of the handler, empty for a function
+	Receiver string
+	// Func the name of the function or the method of the handler, empty for a function literal
+	Func string
+	// Method the HTTP method of the route, e.g. GET
+	Method string
+	// Path the path of the route, e.g. /accounts/{id}
+	Path string
+	// Tags the tags of the operation
+	Tags []string
+}
+
+// PathName names an operation after its method and path, e.g. getAccountsById for GET /accounts/{id}
+// or postAccountsByIdFiles for POST /accounts/{id}/files.
+func (name OperationIDName) PathName() string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(name.Method))
+
+	for _, segment := range strings.Split(name.Path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			sb.WriteString("By")
+		}
+
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			r, size := utf8.DecodeRuneInString(word)
+			sb.WriteString(string(unicode.ToUpper(r)) + word[size:])
+		}
+	}
+
+	return sb.String()
+}
+
+// OperationIDNameFunc names the operationId of an operation, an empty name falls back to its method and path.
+type OperationIDNameFunc func(name OperationIDName) (string, error)
+
+// OperationIDNamer returns the OperationIDNameFunc of a naming strategy:
+// func, receiver, path or a Go template executed with an OperationIDName, e.g. {{.Receiver}}{{.Func}}.
+// An empty strategy names no operation.
+func OperationIDNamer(strategy string) (OperationIDNameFunc, error) {
+	switch {
+	case strategy == "":
+		return nil, nil
+	case strategy == OperationIDFunc:
+		return func(name OperationIDName) (string, error) {
+			return name.Func, nil
+		}, nil
+	case strategy == OperationIDReceiver:
+		return func(name OperationIDName) (string, error) {
+			if name.Receiver == "" || name.Func == "" {
+				return name.Func, nil
+			}
+
+			return name.Receiver + "." + name.Func, nil
+		}, nil
+	case strategy == OperationIDPath:
+		return func(name OperationIDName) (string, error) {
+			return name.PathName(), nil
+		}, nil
+	case strings.Contains(strategy, "{{"):
+		tmpl, err := template.New("operationId").Parse(strategy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operationId naming template: %w", err)
+		}
+
+		return func(name OperationIDName) (string, error) {
+			var sb strings.Builder
+			if err := tmpl.Execute(&sb, name); err != nil {
+				return "", err
+			}
+
+			return strings.TrimSpace(sb.String()), nil
+		}, nil
+	}
+
+	return nil, fmt.Errorf("not supported %s operationId naming strategy", strategy)
+}
+
+// unnamedOperation an operation without @ID, to be named by the operationId naming strategy.
+type unnamedOperation struct {
+	operation *spec.Operation
+	name      OperationIDName
+}
+
+// addUnnamedOperation records an operation without @ID, once it is added to the paths with a route.
+func (parser *Parser) addUnnamedOperation(operation *Operation, op *spec.Operation, routeProperties RouteProperties) {
+	if parser.operationIDNameFunc == nil || op.ID != "" {
+		return
+	}
+
+	parser.unnamedOperations = append(parser.unnamedOperations, unnamedOperation{
+		operation: op,
+		name: OperationIDName{
+			PkgPath:  operation.handler.pkgPath,
+			Receiver: operation.handler.recv,
+			Func:     operation.handler.name,
+			Method:   routeProperties.HTTPMethod,
+			Path:     routeProperties.Path,
+			Tags:     op.Tags,
+		},
+	})
+}
+
+// nameOperations names the operations without @ID with the operationId naming strategy, in the order of their paths
+// and methods, a name already given to another operation gets a numeric suffix.
+func (parser *Parser) nameOperations() error {
+	if len(parser.unnamedOperations) == 0 {
+		return nil
+	}
+
+	taken := make(map[string]bool)
+	for _, item := range parser.swagger.Paths.Paths {
+		for method := range allMethod {
+			if op := *refRouteMethodOp(&item, method); op != nil && op.ID != "" {
+				taken[op.ID] = true
+			}
+		}
+	}
+
+	sort.SliceStable(parser.unnamedOperations, func(i, j int) bool {
+		a, b := parser.unnamedOperations[i].name, parser.unnamedOperations[j].name
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+
+		return a.Method < b.Method
+	})
+
+	for _, unnamed := range parser.unnamedOperations {
+		name, err := parser.operationIDNameFunc(unnamed.name)
+		if err != nil {
+			err = fmt.Errorf("cannot name the operationId of %s %s: %w", unnamed.name.Method, unnamed.name.Path, err)
+			if parser.Strict {
+				return err
+			}
+			parser.debug.Printf("warning: %s, using %s", err, unnamed.name.PathName())
+		}
+		if name == "" {
+			name = unnamed.name.PathName()
+		}
+
+		unique := name
+		for i := 2; taken[unique]; i++ {
+			unique = fmt.Sprintf("%s_%d", name, i)
+		}
+		if unique != name {
+			parser.debug.Printf("OperationId %s of %s %s is already taken, using %s",
+				name, unnamed.name.Method, unnamed.name.Path, unique)
+		}
+
+		taken[unique] = true
+		unnamed.operation.ID = unique
+	}
+
+	parser.unnamedOperations = nil
+
+	return nil
+}
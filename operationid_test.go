@@ -0,0 +1,137 @@
+package swag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationIDNamer(t *testing.T) {
+	t.Parallel()
+
+	name := OperationIDName{
+		PkgPath:  "example.com/svc/controller",
+		Receiver: "Controller",
+		Func:     "ShowAccount",
+		Method:   "GET",
+		Path:     "/accounts/{id}/bank_accounts",
+		Tags:     []string{"accounts"},
+	}
+
+	for strategy, expected := range map[string]string{
+		OperationIDFunc:               "ShowAccount",
+		OperationIDReceiver:           "Controller.ShowAccount",
+		OperationIDPath:               "getAccountsByIdBankAccounts",
+		"{{index .Tags 0}}_{{.Func}}": "accounts_ShowAccount",
+	} {
+		namer, err := OperationIDNamer(strategy)
+		assert.NoError(t, err)
+		actual, err := namer(name)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, strategy)
+	}
+
+	namer, err := OperationIDNamer(OperationIDReceiver)
+	assert.NoError(t, err)
+	actual, err := namer(OperationIDName{Func: "ListAccounts"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ListAccounts", actual)
+
+	namer, err = OperationIDNamer("")
+	assert.NoError(t, err)
+	assert.Nil(t, namer)
+
+	for _, strategy := range []string{"name", "{{.Func"} {
+		_, err = OperationIDNamer(strategy)
+		assert.Error(t, err, strategy)
+	}
+
+	namer, err = OperationIDNamer("{{index .Tags 1}}")
+	assert.NoError(t, err)
+	_, err = namer(name)
+	assert.Error(t, err)
+}
+
+func TestParseOperationIDNaming(t *testing.T) {
+	t.Parallel()
+
+	accounts := `
+package accounts
+
+type Controller struct{}
+
+// @Success 200
+// @Router /accounts/{id} [get]
+func (c *Controller) Show() {
+}
+
+// @Success 200
+// @Router /accounts [get]
+func List() {
+}
+
+// @ID List
+// @Success 200
+// @Router /accounts [post]
+func Create() {
+}
+`
+	users := `
+package users
+
+// @Success 200
+// @Router /users [get]
+// @Router /members [get]
+func List() {
+}
+`
+	namer, err := OperationIDNamer(OperationIDFunc)
+	assert.NoError(t, err)
+
+	p := New(SetOperationIDNameFunc(namer))
+	assert.NoError(t, p.packages.ParseFile("example.com/svc/accounts", "accounts/accounts.go", accounts, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("example.com/svc/users", "users/users.go", users, ParseAll))
+
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+	assert.NoError(t, p.nameOperations())
+	assert.NoError(t, p.checkOperationIDUniqueness())
+
+	paths := p.swagger.Paths.Paths
+	assert.Equal(t, "List", paths["/accounts"].Post.ID)
+	assert.Equal(t, "List_2", paths["/accounts"].Get.ID)
+	assert.Equal(t, "Show", paths["/accounts/{id}"].Get.ID)
+	assert.Equal(t, "List_3", paths["/members"].Get.ID)
+	assert.Equal(t, "List_4", paths["/users"].Get.ID)
+}
+
+func TestParseOperationIDNamingTemplateError(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package accounts
+
+// @Success 200
+// @Router /accounts [get]
+func List() {
+}
+`
+	namer, err := OperationIDNamer("{{index .Tags 0}}_{{.Func}}")
+	assert.NoError(t, err)
+
+	logger := &testLogger{}
+	p := New(SetOperationIDNameFunc(namer), SetDebugger(logger))
+	assert.NoError(t, p.packages.ParseFile("accounts", "accounts/accounts.go", src, ParseAll))
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+	assert.NoError(t, p.nameOperations())
+	assert.Equal(t, "getAccounts", p.swagger.Paths.Paths["/accounts"].Get.ID)
+	assert.Contains(t, strings.Join(logger.Messages, "\n"), "warning: cannot name the operationId of GET /accounts")
+
+	p = New(SetOperationIDNameFunc(namer), SetStrict(true))
+	assert.NoError(t, p.packages.ParseFile("accounts", "accounts/accounts.go", src, ParseAll))
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+	assert.ErrorContains(t, p.nameOperations(), "cannot name the operationId of GET /accounts")
+}
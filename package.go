@@ -22,6 +22,9 @@ type PackageDefinitions struct {
 	// const variables in order in this package
 	OrderedConst []*ConstVariable
 
+	// package-level variables in this package, map key is the name
+	VariableTable map[string]*ConstVariable
+
 	// package name
 	Name string
 
@@ -44,6 +47,7 @@ func NewPackageDefinitions(name, pkgPath string) *PackageDefinitions {
 		Files:           make(map[string]*ast.File),
 		TypeDefinitions: make(map[string]*TypeSpecDef),
 		ConstTable:      make(map[string]*ConstVariable),
+		VariableTable:   make(map[string]*ConstVariable),
 	}
 }
 
@@ -71,6 +75,7 @@ func (pkg *PackageDefinitions) AddConst(astFile *ast.File, valueSpec *ast.ValueS
 		//take the nearest line as comment from comment list or doc list. comment list first.
 		if valueSpec.Comment != nil && len(valueSpec.Comment.List) > 0 {
 			variable.Comment = valueSpec.Comment.List[0].Text
+			variable.lineComment = strings.TrimSpace(valueSpec.Comment.Text())
 		} else if valueSpec.Doc != nil && len(valueSpec.Doc.List) > 0 {
 			variable.Comment = valueSpec.Doc.List[len(valueSpec.Doc.List)-1].Text
 		}
@@ -80,6 +85,23 @@ func (pkg *PackageDefinitions) AddConst(astFile *ast.File, valueSpec *ast.ValueS
 	return pkg
 }
 
+// AddVariable add a package-level variable, its value is kept as an expression.
+func (pkg *PackageDefinitions) AddVariable(astFile *ast.File, valueSpec *ast.ValueSpec) *PackageDefinitions {
+	if pkg.VariableTable == nil {
+		pkg.VariableTable = make(map[string]*ConstVariable)
+	}
+	for i := 0; i < len(valueSpec.Names) && i < len(valueSpec.Values); i++ {
+		pkg.VariableTable[valueSpec.Names[i].Name] = &ConstVariable{
+			Name:  valueSpec.Names[i],
+			Type:  valueSpec.Type,
+			Value: valueSpec.Values[i],
+			File:  astFile,
+			Pkg:   pkg,
+		}
+	}
+	return pkg
+}
+
 func (pkg *PackageDefinitions) evaluateConstValue(file *ast.File, iota int, expr ast.Expr, globalEvaluator ConstVariableGlobalEvaluator, recursiveStack map[string]struct{}) (interface{}, ast.Expr) {
 	switch valueExpr := expr.(type) {
 	case *ast.Ident:
@@ -123,6 +123,7 @@ func (pkgDefs *PackagesDefinitions) ParseTypes() (map[*TypeSpecDef]*Schema, erro
 	}
 	pkgDefs.removeAllNotUniqueTypes()
 	pkgDefs.evaluateAllConstVariables()
+	pkgDefs.collectStringMethods()
 	pkgDefs.collectConstEnums(parsedSchemas)
 	return parsedSchemas, nil
 }
@@ -188,6 +189,9 @@ func (pkgDefs *PackagesDefinitions) parseTypesFromFile(astFile *ast.File, packag
 		} else if generalDeclaration.Tok == token.CONST {
 			// collect consts
 			pkgDefs.collectConstVariables(astFile, packagePath, generalDeclaration)
+		} else if generalDeclaration.Tok == token.VAR {
+			// collect package-level variables, which examples may refer to
+			pkgDefs.collectVariables(astFile, packagePath, generalDeclaration)
 		}
 	}
 }
@@ -303,6 +307,20 @@ func (pkgDefs *PackagesDefinitions) collectConstVariables(astFile *ast.File, pac
 	}
 }
 
+func (pkgDefs *PackagesDefinitions) collectVariables(astFile *ast.File, packagePath string, generalDeclaration *ast.GenDecl) {
+	pkg, ok := pkgDefs.packages[packagePath]
+	if !ok {
+		pkg = NewPackageDefinitions(astFile.Name.Name, packagePath)
+		pkgDefs.packages[packagePath] = pkg
+	}
+
+	for _, astSpec := range generalDeclaration.Specs {
+		if valueSpec, ok := astSpec.(*ast.ValueSpec); ok {
+			pkg.AddVariable(astFile, valueSpec)
+		}
+	}
+}
+
 func (pkgDefs *PackagesDefinitions) evaluateAllConstVariables() {
 	for _, pkg := range pkgDefs.packages {
 		for _, constVar := range pkg.OrderedConst {
@@ -367,6 +385,53 @@ func (pkgDefs *PackagesDefinitions) EvaluateConstValueByName(file *ast.File, pkg
 	return nil, nil
 }
 
+// FindVariable find a package-level variable by name.
+func (pkgDefs *PackagesDefinitions) FindVariable(file *ast.File, pkgName, variableName string) *ConstVariable {
+	matchedPkgPaths, externalPkgPaths := pkgDefs.findPackagePathFromImports(pkgName, file)
+	for _, pkgPath := range matchedPkgPaths {
+		if pkg, ok := pkgDefs.packages[pkgPath]; ok {
+			if variable, ok := pkg.VariableTable[variableName]; ok {
+				return variable
+			}
+		}
+	}
+	if pkgDefs.parseDependency > 0 {
+		for _, pkgPath := range externalPkgPaths {
+			if err := pkgDefs.loadExternalPackage(pkgPath); err == nil {
+				if pkg, ok := pkgDefs.packages[pkgPath]; ok {
+					if variable, ok := pkg.VariableTable[variableName]; ok {
+						return variable
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findFileOfNode find the file a node, such as a struct field, is declared in.
+func (pkgDefs *PackagesDefinitions) findFileOfNode(node ast.Node) *AstFileInfo {
+	for astFile, info := range pkgDefs.files {
+		// positions of different files may overlap, as each file has its own FileSet
+		if node.Pos() < astFile.Pos() || node.End() > astFile.End() {
+			continue
+		}
+
+		var found bool
+		ast.Inspect(astFile, func(n ast.Node) bool {
+			if n == node {
+				found = true
+			}
+			return !found
+		})
+		if found {
+			return info
+		}
+	}
+
+	return nil
+}
+
 func (pkgDefs *PackagesDefinitions) collectConstEnums(parsedSchemas map[*TypeSpecDef]*Schema) {
 	for _, pkg := range pkgDefs.packages {
 		for _, constVar := range pkg.OrderedConst {
@@ -397,15 +462,54 @@ func (pkgDefs *PackagesDefinitions) collectConstEnums(parsedSchemas map[*TypeSpe
 			}
 
 			enumValue := EnumValue{
-				key:     name,
-				Value:   constVar.Value,
-				Comment: commentWithoutNameOverride(constVar.Comment),
+				key:         name,
+				Value:       constVar.Value,
+				Comment:     commentWithoutNameOverride(constVar.Comment),
+				StringValue: typeDef.stringValues[constVar.Name.Name],
 			}
 			typeDef.Enums = append(typeDef.Enums, enumValue)
 		}
 	}
 }
 
+// collectStringMethods records the text printed by String() methods of named types,
+// either generated by stringer or written as a switch over the consts.
+func (pkgDefs *PackagesDefinitions) collectStringMethods() {
+	for astFile, info := range pkgDefs.files {
+		pkg, ok := pkgDefs.packages[info.PackagePath]
+		if !ok {
+			continue
+		}
+
+		stringer := parseStringerCommand(astFile)
+		for _, astDeclaration := range astFile.Decls {
+			funcDeclaration, ok := astDeclaration.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			typeName, ok := stringMethodReceiver(funcDeclaration)
+			if !ok {
+				continue
+			}
+			typeDef, ok := pkg.TypeDefinitions[typeName]
+			if !ok {
+				continue
+			}
+
+			var values map[string]string
+			if stringer != nil {
+				values = stringer.values(pkg, typeName)
+			}
+			if len(values) == 0 {
+				values = switchStringValues(funcDeclaration)
+			}
+			if len(values) > 0 {
+				typeDef.stringValues = values
+			}
+		}
+	}
+}
+
 func (pkgDefs *PackagesDefinitions) removeAllNotUniqueTypes() {
 	for key, ud := range pkgDefs.uniqueDefinitions {
 		if ud == nil {
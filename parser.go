@@ -15,6 +15,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -68,6 +69,8 @@ const (
 	xCodeSamplesAttr        = "@x-codesamples"
 	scopeAttrPrefix         = "@scope."
 	stateAttr               = "@state"
+	fragmentAttr            = "@fragment"
+	useAttr                 = "@use"
 )
 
 // ParseFlag determine what to parse
@@ -106,6 +109,7 @@ var allMethod = map[string]struct{}{
 	http.MethodOptions: {},
 	http.MethodHead:    {},
 	http.MethodPatch:   {},
+	http.MethodTrace:   {},
 }
 
 // Parser implements a parser for Go source files.
@@ -122,6 +126,9 @@ type Parser struct {
 	// outputSchemas store schemas which will be export to swagger
 	outputSchemas map[*TypeSpecDef]*Schema
 
+	// promotedFields store the embedding depth of the properties of parsed struct schemas
+	promotedFields map[*spec.Schema]map[string]promotedField
+
 	// PropNamingStrategy naming strategy
 	PropNamingStrategy string
 
@@ -180,12 +187,79 @@ type Parser struct {
 	// HostState is the state of the host
 	HostState string
 
+	// APIVersion the version of the API to generate the docs of, leaving out the operations and the fields
+	// not in it by their @Since and @Until, empty for all of them
+	APIVersion string
+
 	// ParseFuncBody whether swag should parse api info inside of funcs
 	ParseFuncBody bool
 
 	// UseStructName Dont use those ugly full-path names when using dependency flag
 	UseStructName bool
 
+	// StringEnums use the String() values of integer enums generated by stringer or written as a switch
+	StringEnums bool
+
+	// EmbeddedAllOf compose embedded structs with allOf instead of flattening their fields
+	EmbeddedAllOf bool
+
+	// OpenAPIVersion the version of the OpenAPI document, 3.0 (default) or 3.1
+	OpenAPIVersion string
+
+	// ParseProtobuf document structs generated by protoc-gen-go as protojson encodes them
+	ParseProtobuf bool
+
+	// StrictObjects forbid the properties a struct does not declare with additionalProperties false
+	StrictObjects bool
+
+	// InferRoutes infer the routes of the operations without @Router from the code registering their handlers
+	InferRoutes bool
+
+	// InferHandlerTypes synthesize the body param and the responses an operation lacks from the body of its handler
+	InferHandlerTypes bool
+
+	// inferredRoutes the routes inferred for the handlers, by the first line of their doc comments
+	inferredRoutes map[*ast.Comment][]RouteProperties
+
+	// inferredRouteDocs the doc comments of the handlers registered as function literals, by their files
+	inferredRouteDocs map[*ast.File][]*ast.CommentGroup
+
+	// generalInfoFile the syntax tree of the main API file, which the types named by the general API info are resolved in
+	generalInfoFile *ast.File
+
+	// fragments the fragments declared by @fragment in the general API info, by their names
+	fragments map[string]fragment
+
+	// pathItems the paths declared by @Path blocks
+	pathItems map[string]bool
+
+	// defaultFailures the responses declared by @Failure.default, added to the operations lacking their statuses
+	defaultFailures []defaultFailure
+
+	// operationDefaults the defaults of the operations of the packages and the receiver types, by their paths
+	operationDefaults map[string]*operationDefaults
+
+	// operationIDNameFunc names the operations without @ID, nil leaves them without an operationId
+	operationIDNameFunc OperationIDNameFunc
+
+	// unnamedOperations the operations without @ID, named by operationIDNameFunc once all of them are parsed
+	unnamedOperations []unnamedOperation
+
+	// allOfMembers the references to the definitions of the structs embedded with allOf, kept open with StrictObjects
+	allOfMembers map[string]struct{}
+
+	// definitionNameFunc names the definitions, nil keeps the default names
+	definitionNameFunc DefinitionNameFunc
+
+	// definitionNames store the names given to the definitions by definitionNameFunc
+	definitionNames map[*TypeSpecDef]string
+
+	// definitionOwners store the type each name given by definitionNameFunc belongs to
+	definitionOwners map[string]*TypeSpecDef
+
+	// definitionBaseNames store the names given to the definitions by definitionNameFunc before their numeric suffixes
+	definitionBaseNames map[*TypeSpecDef]string
+
 	// searchDir holds the current search directory for file operations
 	searchDir string
 }
@@ -238,14 +312,19 @@ func New(options ...func(*Parser)) *Parser {
 				Extensions: nil,
 			},
 		},
-		packages:           NewPackagesDefinitions(),
-		debug:              log.New(os.Stdout, "", log.LstdFlags),
-		parsedSchemas:      make(map[*TypeSpecDef]*Schema),
-		outputSchemas:      make(map[*TypeSpecDef]*Schema),
-		excludes:           make(map[string]struct{}),
-		tags:               make(map[string]struct{}),
-		fieldParserFactory: newTagBaseFieldParser,
-		Overrides:          make(map[string]string),
+		packages:            NewPackagesDefinitions(),
+		debug:               log.New(os.Stdout, "", log.LstdFlags),
+		parsedSchemas:       make(map[*TypeSpecDef]*Schema),
+		outputSchemas:       make(map[*TypeSpecDef]*Schema),
+		promotedFields:      make(map[*spec.Schema]map[string]promotedField),
+		allOfMembers:        make(map[string]struct{}),
+		definitionNames:     make(map[*TypeSpecDef]string),
+		definitionOwners:    make(map[string]*TypeSpecDef),
+		definitionBaseNames: make(map[*TypeSpecDef]string),
+		excludes:            make(map[string]struct{}),
+		tags:                make(map[string]struct{}),
+		fieldParserFactory:  newTagBaseFieldParser,
+		Overrides:           make(map[string]string),
 	}
 
 	for _, option := range options {
@@ -274,6 +353,76 @@ func SetUseStructName(useStructName bool) func(*Parser) {
 	}
 }
 
+// SetStringEnums sets whether integer enums with a String() method are documented by their string values.
+func SetStringEnums(stringEnums bool) func(*Parser) {
+	return func(p *Parser) {
+		p.StringEnums = stringEnums
+	}
+}
+
+// SetEmbeddedAllOf sets whether embedded structs are composed with allOf instead of flattened into their parent.
+func SetEmbeddedAllOf(embeddedAllOf bool) func(*Parser) {
+	return func(p *Parser) {
+		p.EmbeddedAllOf = embeddedAllOf
+	}
+}
+
+// SetOpenAPIVersion sets the version of the OpenAPI document, 3.0 (default) or 3.1.
+func SetOpenAPIVersion(version string) func(*Parser) {
+	return func(p *Parser) {
+		p.OpenAPIVersion = version
+	}
+}
+
+// SetParseProtobuf sets whether structs generated by protoc-gen-go are documented as protojson encodes them.
+func SetParseProtobuf(parseProtobuf bool) func(*Parser) {
+	return func(p *Parser) {
+		p.ParseProtobuf = parseProtobuf
+	}
+}
+
+// SetStrictObjects sets whether the schemas of structs forbid the properties they do not declare.
+func SetStrictObjects(strictObjects bool) func(*Parser) {
+	return func(p *Parser) {
+		p.StrictObjects = strictObjects
+	}
+}
+
+// SetInferRoutes sets whether the routes of the operations without @Router are inferred from the code registering their handlers.
+func SetInferRoutes(inferRoutes bool) func(*Parser) {
+	return func(p *Parser) {
+		p.InferRoutes = inferRoutes
+	}
+}
+
+// SetInferHandlerTypes sets whether the body param and the responses an operation lacks are inferred from the body of its handler.
+func SetInferHandlerTypes(inferHandlerTypes bool) func(*Parser) {
+	return func(p *Parser) {
+		p.InferHandlerTypes = inferHandlerTypes
+	}
+}
+
+// SetAPIVersion sets the version of the API to generate the docs of, e.g. v1.3.
+func SetAPIVersion(version string) func(*Parser) {
+	return func(p *Parser) {
+		p.APIVersion = version
+	}
+}
+
+// SetOperationIDNameFunc sets the naming strategy of the operationIds of the operations without @ID, see OperationIDNamer.
+func SetOperationIDNameFunc(nameFunc OperationIDNameFunc) func(*Parser) {
+	return func(p *Parser) {
+		p.operationIDNameFunc = nameFunc
+	}
+}
+
+// SetDefinitionNameFunc sets the naming strategy of the definitions, see DefinitionNamer.
+func SetDefinitionNameFunc(nameFunc DefinitionNameFunc) func(*Parser) {
+	return func(p *Parser) {
+		p.definitionNameFunc = nameFunc
+	}
+}
+
 // SetMarkdownFileDirectory sets the directory to search for markdown files.
 func SetMarkdownFileDirectory(directoryPath string) func(*Parser) {
 	return func(p *Parser) {
@@ -401,6 +550,13 @@ func (parser *Parser) skipPackageByPrefix(pkgpath string) bool {
 
 // ParseAPIMultiSearchDir is like ParseAPI but for multiple search dirs.
 func (parser *Parser) ParseAPIMultiSearchDir(searchDirs []string, mainAPIFile string, parseDepth int) error {
+	if parser.APIVersion != "" {
+		_, err := canonicalAPIVersion(parser.APIVersion)
+		if err != nil {
+			return fmt.Errorf("api version: %w", err)
+		}
+	}
+
 	for _, searchDir := range searchDirs {
 		parser.searchDir = searchDir // Set current search directory
 		parser.debug.Printf("Generate general API Info, search dir:%s", searchDir)
@@ -464,16 +620,41 @@ func (parser *Parser) ParseAPIMultiSearchDir(searchDirs []string, mainAPIFile st
 		return err
 	}
 
+	// the docs of a version of the API
+	if parser.APIVersion != "" {
+		parser.swagger.Info.Version = parser.APIVersion
+	}
+
 	parser.parsedSchemas, err = parser.packages.ParseTypes()
 	if err != nil {
 		return err
 	}
 
+	if parser.InferRoutes {
+		err = parser.inferRoutes()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = parser.packages.RangeFiles(parser.ParsePathItemInfo)
+	if err != nil {
+		return err
+	}
+
 	err = parser.packages.RangeFiles(parser.ParseRouterAPIInfo)
 	if err != nil {
 		return err
 	}
 
+	parser.openAllOfMembers()
+	parser.renameDefinitions()
+
+	err = parser.nameOperations()
+	if err != nil {
+		return err
+	}
+
 	return parser.checkOperationIDUniqueness()
 }
 
@@ -511,6 +692,11 @@ func (parser *Parser) ParseGeneralAPIInfo(mainAPIFile string) error {
 	}
 
 	parser.swagger.Swagger = "3.0.0"
+	if strings.HasPrefix(parser.OpenAPIVersion, "3.1") {
+		parser.swagger.Swagger = "3.1.0"
+	}
+
+	parser.generalInfoFile = parser.packageFile(mainAPIFile, fileTree)
 
 	for _, comment := range fileTree.Comments {
 		comments := strings.Split(comment.Text(), "\n")
@@ -527,6 +713,23 @@ func (parser *Parser) ParseGeneralAPIInfo(mainAPIFile string) error {
 	return nil
 }
 
+// packageFile returns the syntax tree of a file parsed with its package, so that the names it declares and imports
+// are resolved as in the rest of the package, or else fileTree, the file parsed alone.
+func (parser *Parser) packageFile(path string, fileTree *ast.File) *ast.File {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fileTree
+	}
+
+	for file, fileInfo := range parser.packages.files {
+		if filePath, err := filepath.Abs(fileInfo.Path); err == nil && filePath == absPath {
+			return file
+		}
+	}
+
+	return fileTree
+}
+
 func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 	previousAttribute := ""
 	var tag *spec.Tag
@@ -655,6 +858,18 @@ func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 		case securityAttr:
 			parser.swagger.Security = append(parser.swagger.Security, parseSecurity(value))
 
+		case fragmentAttr:
+			err := parser.parseFragment(value, comments, &line)
+			if err != nil {
+				return err
+			}
+
+		case defaultFailureAttr:
+			err := parser.parseDefaultFailure(value)
+			if err != nil {
+				return err
+			}
+
 		case "@query.collection.format":
 			parser.collectionFormatInQuery = TransToValidCollectionFormat(value)
 
@@ -918,13 +1133,22 @@ func (parser *Parser) ParseProduceComment(commentLine string) error {
 }
 
 func isGeneralAPIComment(comments []string) bool {
+	inFragment := false
 	for _, commentLine := range comments {
+		// the annotations of a fragment belong to the operations using it
+		if inFragment && isFragmentLine(commentLine) {
+			continue
+		}
+		inFragment = false
+
 		commentLine = strings.TrimSpace(commentLine)
 		if len(commentLine) == 0 {
 			continue
 		}
 		attribute := strings.ToLower(FieldsByAnySpace(commentLine, 2)[0])
 		switch attribute {
+		case fragmentAttr:
+			inFragment = true
 		// The @summary, @router, @success, @failure annotation belongs to Operation
 		case summaryAttr, routerAttr, successAttr, failureAttr, responseAttr:
 			return false
@@ -1152,6 +1376,13 @@ func (parser *Parser) ParseRouterAPIInfo(fileInfo *AstFileInfo) error {
 		}
 	}
 
+	// the handlers registered as function literals, whose comments are not the docs of declarations
+	for _, doc := range parser.inferredRouteDocs[fileInfo.File] {
+		if err := parser.parseRouterAPIInfoComment(doc.List, fileInfo); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1159,6 +1390,7 @@ func (parser *Parser) parseRouterAPIInfoComment(comments []*ast.Comment, fileInf
 	if parser.matchTags(comments) && matchExtension(parser.parseExtension, comments) {
 		// for per 'function' comment, create a new 'Operation' object
 		operation := NewOperation(parser, SetCodeExampleFilesDirectory(parser.codeExampleFilesDir))
+		operation.handler = handlerOfDoc(fileInfo, comments)
 		for _, comment := range comments {
 			err := operation.ParseComment(comment.Text, fileInfo.File)
 			if err != nil {
@@ -1168,7 +1400,28 @@ func (parser *Parser) parseRouterAPIInfoComment(comments []*ast.Comment, fileInf
 				return nil
 			}
 		}
-		err := processRouterOperation(parser, operation)
+		if !parser.inAPIVersion(operation.since, operation.until) {
+			return nil
+		}
+		err := parser.applyDefaults(operation)
+		if err != nil {
+			return fmt.Errorf("ParseComment error in file %s: %+v", fileInfo.Path, err)
+		}
+		if len(operation.RouterProperties) == 0 {
+			operation.RouterProperties = parser.inferredRoutes[comments[0]]
+		}
+		if parser.InferHandlerTypes {
+			err = parser.inferHandlerTypes(operation, comments, fileInfo)
+			if err != nil {
+				return fmt.Errorf("ParseComment error in file %s: %+v", fileInfo.Path, err)
+			}
+		}
+		err = parser.applyDefaultFailures(operation, fileInfo.File)
+		if err != nil {
+			return fmt.Errorf("ParseComment error in file %s: %+v", fileInfo.Path, err)
+		}
+		parser.applyDeprecation(operation)
+		err = processRouterOperation(parser, operation)
 		if err != nil {
 			return err
 		}
@@ -1193,24 +1446,42 @@ func refRouteMethodOp(item *spec.PathItem, method string) (op **spec.Operation)
 		op = &item.Head
 	case http.MethodOptions:
 		op = &item.Options
+	case http.MethodTrace:
+		op = refTraceOp(item, false)
 	}
 
 	return
 }
 
 func processRouterOperation(parser *Parser, operation *Operation) error {
+	err := checkPathParams(parser, operation)
+	if err != nil {
+		return err
+	}
+
 	for _, routeProperties := range operation.RouterProperties {
 		var (
 			pathItem spec.PathItem
 			ok       bool
 		)
 
+		// the regular expressions of chi and gorilla/mux are not a part of an OpenAPI path
+		var routeParams []routeParam
+		routeProperties.Path, routeParams = routePathParams(routeProperties.Path)
+
 		pathItem, ok = parser.swagger.Paths.Paths[routeProperties.Path]
 		if !ok {
 			pathItem = spec.PathItem{}
 		}
 
 		op := refRouteMethodOp(&pathItem, routeProperties.HTTPMethod)
+		if routeProperties.HTTPMethod == http.MethodTrace {
+			// Swagger 2.0 lacks TRACE, its operation is kept in an extension of the path item
+			op = refTraceOp(&pathItem, true)
+		}
+
+		// the params the path item declares are shared by its operations
+		params, undeclared := hoistPathItemParams(&pathItem, operation.Parameters, routeParams)
 
 		// check if we already have an operation for this path and method
 		if *op != nil {
@@ -1225,16 +1496,17 @@ func processRouterOperation(parser *Parser, operation *Operation) error {
 		if len(operation.RouterProperties) > 1 {
 			newOp := *operation
 			var validParams []spec.Parameter
-			for _, param := range newOp.Operation.OperationProps.Parameters {
-				if param.In == "path" && !strings.Contains(routeProperties.Path, param.Name) {
+			for _, param := range params {
+				if param.In == "path" && !hasRouteParam(routeParams, param.Name) {
 					// This path param is not actually contained in the path, skip adding it to the final params
 					continue
 				}
 				validParams = append(validParams, param)
 			}
-			newOp.Operation.OperationProps.Parameters = validParams
+			newOp.Operation.OperationProps.Parameters = addRouteParams(parser, validParams, undeclared)
 			*op = &newOp.Operation
 		} else {
+			operation.Operation.Parameters = addRouteParams(parser, params, undeclared)
 			*op = &operation.Operation
 		}
 
@@ -1242,6 +1514,8 @@ func processRouterOperation(parser *Parser, operation *Operation) error {
 			(*op).Deprecated = routeProperties.Deprecated
 		}
 
+		parser.addUnnamedOperation(operation, *op, routeProperties)
+
 		parser.swagger.Paths.Paths[routeProperties.Path] = pathItem
 	}
 
@@ -1277,11 +1551,25 @@ func (parser *Parser) getTypeSchema(typeName string, file *ast.File, ref bool) (
 		return TransToValidPrimitiveSchema(typeName), nil
 	}
 
+	if isXMLNameType(typeName, file) {
+		return xmlNameSchema(), nil
+	}
+
+	if parser.ParseProtobuf {
+		if schema, ok := protobufWellKnownSchema(typeName, file); ok {
+			return schema, nil
+		}
+	}
+
 	schemaType, err := convertFromSpecificToPrimitive(typeName)
 	if err == nil {
 		return PrimitiveSchema(schemaType), nil
 	}
 
+	if isMultipartFileHeader(typeName, file) {
+		return BinarySchema(), nil
+	}
+
 	typeSpecDef := parser.packages.FindTypeSpec(typeName, file)
 	if typeSpecDef == nil {
 		return nil, fmt.Errorf("cannot find type definition: %s", typeName)
@@ -1392,11 +1680,27 @@ func (parser *Parser) ParseDefinition(typeSpecDef *TypeSpecDef) (*Schema, error)
 		}
 	}
 
+	if parser.definitionNameFunc != nil && typeSpecDef.Alias() == "" &&
+		(typeSpecDef.genericBase != nil || !ignoreNameOverride(typeSpecDef.Name())) {
+		schemaName, err := parser.definitionName(typeSpecDef)
+		if err != nil {
+			return nil, err
+		}
+		typeSpecDef.SchemaName = schemaName
+		typeName = typeSpecDef.SchemaName
+	}
+
 	parser.structStack = append(parser.structStack, typeSpecDef)
 
 	parser.debug.Printf("Generating %s", typeName)
 
-	definition, err := parser.parseTypeExpr(typeSpecDef.File, typeSpecDef.TypeSpec.Type, false)
+	typeExpr := typeSpecDef.TypeSpec.Type
+	if typeSpecDef.TypeSpec.TypeParams != nil && len(typeSpecDef.TypeSpec.TypeParams.List) > 0 {
+		// a generic type which is not instantiated
+		typeExpr = parser.packages.resolveTypeParamConstraints(typeSpecDef)
+	}
+
+	definition, err := parser.parseTypeExpr(typeSpecDef.File, typeExpr, false)
 	if err != nil {
 		parser.debug.Printf("Error parsing type definition '%s': %s", typeName, err)
 		return nil, err
@@ -1409,11 +1713,31 @@ func (parser *Parser) ParseDefinition(typeSpecDef *TypeSpecDef) (*Schema, error)
 		}
 	}
 
-	if len(typeSpecDef.Enums) > 0 {
+	err = parser.parseDefinitionAnnotations(definition, typeSpecDef)
+	if err != nil {
+		return nil, err
+	}
+
+	enums := typeSpecDef.Enums
+	if parser.StringEnums && len(definition.Type) == 1 && definition.Type[0] == INTEGER {
+		if stringEnums, dropped := stringEnumValues(enums); len(stringEnums) > 0 {
+			parser.debug.Printf("Using String() values as enum of %s", typeName)
+			if len(dropped) > 0 {
+				parser.debug.Printf("warning: the String() values of %s of %s are unknown, they are not in its enum",
+					strings.Join(dropped, ", "), typeName)
+			}
+
+			enums = stringEnums
+			definition.Type = []string{STRING}
+			definition.Format = ""
+		}
+	}
+
+	if len(enums) > 0 {
 		var varnames []string
 		var enumComments = make(map[string]string)
-		var enumDescriptions = make([]string, 0, len(typeSpecDef.Enums))
-		for _, value := range typeSpecDef.Enums {
+		var enumDescriptions = make([]string, 0, len(enums))
+		for _, value := range enums {
 			definition.Enum = append(definition.Enum, value.Value)
 			varnames = append(varnames, value.key)
 			enumDescriptions = append(enumDescriptions, value.Comment)
@@ -1444,6 +1768,11 @@ func (parser *Parser) ParseDefinition(typeSpecDef *TypeSpecDef) (*Schema, error)
 	}
 	parser.parsedSchemas[typeSpecDef] = &sch
 
+	err = parser.parseDiscriminatorAnnotation(definition, typeSpecDef)
+	if err != nil {
+		return nil, err
+	}
+
 	// update an empty schema as a result of recursion
 	s2, found := parser.outputSchemas[typeSpecDef]
 	if found {
@@ -1494,7 +1823,8 @@ func (parser *Parser) extractDeclarationDescription(typeName string, commentGrou
 	var description string
 
 	for _, commentGroup := range commentGroups {
-		if commentGroup == nil {
+		// the @Description of a @Path block is the one of its path item
+		if commentGroup == nil || isPathItemDoc(commentGroup) {
 			continue
 		}
 
@@ -1572,15 +1902,22 @@ func (parser *Parser) parseTypeExpr(file *ast.File, typeExpr ast.Expr, ref bool)
 		return spec.ArrayProperty(itemSchema), nil
 	// type Foo map[string]Bar
 	case *ast.MapType:
-		if _, ok := expr.Value.(*ast.InterfaceType); ok {
-			return spec.MapProperty(nil), nil
+		schema := spec.MapProperty(nil)
+		if _, ok := expr.Value.(*ast.InterfaceType); !ok {
+			valueSchema, err := parser.parseTypeExpr(file, expr.Value, true)
+			if err != nil {
+				return nil, err
+			}
+			schema = spec.MapProperty(valueSchema)
 		}
-		schema, err := parser.parseTypeExpr(file, expr.Value, true)
-		if err != nil {
-			return nil, err
+
+		if keyType, err := getFieldType(file, expr.Key, nil); err == nil {
+			if keySchema := parser.parseMapKeySchema(file, keyType); keySchema != nil {
+				schema.AddExtension(mapKeyTypeExtension, keySchema)
+			}
 		}
 
-		return spec.MapProperty(schema), nil
+		return schema, nil
 
 	case *ast.FuncType:
 		return nil, ErrFuncTypeField
@@ -1590,11 +1927,84 @@ func (parser *Parser) parseTypeExpr(file *ast.File, typeExpr ast.Expr, ref bool)
 	return parser.parseGenericTypeExpr(file, typeExpr)
 }
 
+// parseMapKeySchema returns the schema of a map key type, or nil for plain string keys.
+func (parser *Parser) parseMapKeySchema(file *ast.File, keyType string) *spec.Schema {
+	if keyType == STRING {
+		return nil
+	}
+
+	schema, err := parser.getTypeSchema(keyType, file, false)
+	if err != nil {
+		parser.debug.Printf("Could not resolve map key type %s: %s", keyType, err)
+		return nil
+	}
+	if len(schema.Type) != 1 || !IsSimplePrimitiveType(schema.Type[0]) {
+		return nil
+	}
+
+	keySchema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:    schema.Type,
+			Format:  schema.Format,
+			Pattern: schema.Pattern,
+			Enum:    schema.Enum,
+		},
+	}
+	if varNames, ok := schema.Extensions[enumVarNamesExtension]; ok {
+		keySchema.AddExtension(enumVarNamesExtension, varNames)
+	}
+
+	typeName := keyType[strings.LastIndexByte(keyType, '.')+1:]
+	if keySchema.Format == "" && strings.EqualFold(typeName, "UUID") {
+		keySchema.Format = "uuid"
+	}
+
+	if keySchema.Type[0] == STRING && keySchema.Format == "" && keySchema.Pattern == "" && len(keySchema.Enum) == 0 {
+		return nil
+	}
+
+	return keySchema
+}
+
 func (parser *Parser) parseStruct(file *ast.File, fields *ast.FieldList) (*spec.Schema, error) {
-	required, properties := make([]string, 0), make(map[string]spec.Schema)
+	var allOf, oneofExclusions []spec.Schema
+	oneofProperties := make(map[string]spec.Schema)
+	var xmlObject *spec.XMLObject
+	candidates := make(map[string][]promotedField)
 
 	for _, field := range fields.List {
-		fieldProps, requiredFromAnon, err := parser.parseStructField(file, field)
+		if xmlName, ok := parseXMLNameField(field); ok {
+			xmlObject = xmlName
+		}
+
+		if parser.ParseProtobuf {
+			oneOf, err := parser.parseProtobufOneof(file, field)
+			if err != nil {
+				if errors.Is(err, ErrSkippedField) {
+					continue
+				}
+
+				return nil, err
+			}
+			if oneOf != nil {
+				for name, property := range oneOf.Properties {
+					oneofProperties[name] = property
+				}
+				oneofExclusions = append(oneofExclusions, oneOf.AllOf...)
+				continue
+			}
+		}
+
+		embeddedSchema, err := parser.parseEmbeddedAllOf(file, field)
+		if err != nil {
+			return nil, err
+		}
+		if embeddedSchema != nil {
+			allOf = append(allOf, *embeddedSchema)
+			continue
+		}
+
+		fieldProps, err := parser.parseStructField(file, field)
 		if err != nil {
 			if errors.Is(err, ErrFuncTypeField) || errors.Is(err, ErrSkippedField) {
 				continue
@@ -1603,78 +2013,163 @@ func (parser *Parser) parseStruct(file *ast.File, fields *ast.FieldList) (*spec.
 			return nil, err
 		}
 
-		if len(fieldProps) == 0 {
+		for _, prop := range fieldProps {
+			candidates[prop.name] = append(candidates[prop.name], prop)
+		}
+	}
+
+	required, properties := make([]string, 0), make(map[string]spec.Schema)
+	promoted := make(map[string]promotedField)
+
+	for name, fields := range candidates {
+		prop, err := parser.dominantField(name, fields)
+		if err != nil {
+			return nil, err
+		}
+		if prop == nil {
 			continue
 		}
 
-		required = append(required, requiredFromAnon...)
+		properties[name] = prop.schema
+		promoted[name] = *prop
+		if prop.required {
+			required = append(required, name)
+		}
+	}
 
-		for k, v := range fieldProps {
-			properties[k] = v
+	for name, property := range oneofProperties {
+		if _, ok := properties[name]; !ok {
+			properties[name] = property
 		}
 	}
 
 	sort.Strings(required)
 
-	return &spec.Schema{
+	schema := &spec.Schema{
 		SchemaProps: spec.SchemaProps{
 			Type:       []string{OBJECT},
 			Properties: properties,
 			Required:   required,
 		},
-	}, nil
+	}
+	parser.promotedFields[schema] = promoted
+
+	// the properties of the oneofs of a protobuf message exclude each other
+	switch len(oneofExclusions) {
+	case 0:
+	case 1:
+		schema.Not = oneofExclusions[0].Not
+	default:
+		schema.AllOf = oneofExclusions
+	}
+
+	if len(allOf) == 0 {
+		schema.XML = xmlObject
+		parser.forbidAdditionalProperties(schema, false)
+
+		return schema, nil
+	}
+
+	if len(properties) > 0 {
+		allOf = append(allOf, *schema)
+	}
+
+	composed := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			AllOf: allOf,
+		},
+		SwaggerSchemaProps: spec.SwaggerSchemaProps{
+			XML: xmlObject,
+		},
+	}
+	parser.forbidAdditionalProperties(composed, true)
+
+	return composed, nil
 }
 
-func (parser *Parser) parseStructField(file *ast.File, field *ast.Field) (map[string]spec.Schema, []string, error) {
+// forbidAdditionalProperties sets additionalProperties false on the schema of a struct with StrictObjects.
+// A composed schema declares properties in its subschemas, which additionalProperties does not see,
+// so it gets unevaluatedProperties false in OpenAPI 3.1 and stays open in 3.0.
+func (parser *Parser) forbidAdditionalProperties(schema *spec.Schema, composed bool) {
+	if !parser.StrictObjects {
+		return
+	}
+
+	if !composed {
+		schema.AdditionalProperties = &spec.SchemaOrBool{Allows: false}
+
+		return
+	}
+
+	if strings.HasPrefix(parser.OpenAPIVersion, "3.1") {
+		addExtraProp(schema, "unevaluatedProperties", false)
+
+		return
+	}
+
+	parser.debug.Printf("Composed schema of a struct allows additional properties, unevaluatedProperties requires OpenAPI 3.1")
+}
+
+func (parser *Parser) parseStructField(file *ast.File, field *ast.Field) ([]promotedField, error) {
 	if field.Tag != nil {
 		skip, ok := reflect.StructTag(strings.ReplaceAll(field.Tag.Value, "`", "")).Lookup("swaggerignore")
 		if ok && strings.EqualFold(skip, "true") {
-			return nil, nil, nil
+			return nil, nil
 		}
 	}
 
 	ps := parser.fieldParserFactory(parser, field)
 
 	if ps.ShouldSkip() {
-		return nil, nil, nil
+		return nil, nil
 	}
 
 	fieldNames, err := ps.FieldNames()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	if len(fieldNames) == 0 {
 		typeName, err := getFieldType(file, field.Type, nil)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		schema, err := parser.getTypeSchema(typeName, file, false)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
+		depths := parser.promotedFields[schema]
+		schema = parser.flattenAllOf(schema)
+
 		if len(schema.Type) > 0 && schema.Type[0] == OBJECT {
 			if len(schema.Properties) == 0 {
-				return nil, nil, nil
+				return nil, nil
 			}
 
-			properties := map[string]spec.Schema{}
+			properties := make([]promotedField, 0, len(schema.Properties))
 			for k, v := range schema.Properties {
-				properties[k] = v
+				properties = append(properties, promotedField{
+					name:       k,
+					schema:     v,
+					required:   slices.Contains(schema.Required, k),
+					depth:      depths[k].depth + 1,
+					tagged:     depths[k].tagged,
+					embeddedIn: typeName,
+				})
 			}
 
-			return properties, schema.SchemaProps.Required, nil
+			return properties, nil
 		}
 		// for alias type of non-struct types ,such as array,map, etc. ignore field tag.
-		return map[string]spec.Schema{typeName: *schema}, nil, nil
+		return []promotedField{{name: typeName, schema: *schema}}, nil
 
 	}
 
 	schema, err := ps.CustomSchema()
 	if err != nil {
-		return nil, nil, fmt.Errorf("%v: %w", fieldNames, err)
+		return nil, fmt.Errorf("%v: %w", fieldNames, err)
 	}
 
 	if schema == nil {
@@ -1688,24 +2183,27 @@ func (parser *Parser) parseStructField(file *ast.File, field *ast.Field) (map[st
 		}
 
 		if err != nil {
-			return nil, nil, fmt.Errorf("%v: %w", fieldNames, err)
+			return nil, fmt.Errorf("%v: %w", fieldNames, err)
 		}
 	}
 
 	err = ps.ComplementSchema(schema)
 	if err != nil {
-		return nil, nil, fmt.Errorf("%v: %w", fieldNames, err)
+		return nil, fmt.Errorf("%v: %w", fieldNames, err)
 	}
 
-	var tagRequired []string
+	if parser.ParseProtobuf && ps.FirstTagValue(protobufTag) != "" {
+		complementProtobufSchema(file, field.Type, schema)
+	}
 
-	required, err := ps.IsRequired()
+	err = parser.complementValueReferences(file, ps, schema)
 	if err != nil {
-		return nil, nil, fmt.Errorf("%v: %w", fieldNames, err)
+		return nil, fmt.Errorf("%v: %w", fieldNames, err)
 	}
 
-	if required {
-		tagRequired = append(tagRequired, fieldNames...)
+	required, err := ps.IsRequired()
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", fieldNames, err)
 	}
 
 	if formName := ps.FormName(); len(formName) > 0 {
@@ -1722,11 +2220,21 @@ func (parser *Parser) parseStructField(file *ast.File, field *ast.Field) (map[st
 			schema.AddExtension(collectionFormatTag, collectionFormat)
 		}
 	}
-	fields := make(map[string]spec.Schema)
-	for _, name := range fieldNames {
-		fields[name] = *schema
+	tagged := ps.FirstTagValue(jsonTag) != "" || ps.FormName() != ""
+
+	fields := make([]promotedField, 0, len(fieldNames))
+	for i, name := range fieldNames {
+		fields = append(fields, promotedField{
+			name:     name,
+			schema:   *schema,
+			required: required,
+			tagged:   tagged,
+		})
+		if i < len(field.Names) {
+			parser.complementXMLSchema(field, &fields[i].schema, field.Names[i].Name, name)
+		}
 	}
-	return fields, tagRequired, nil
+	return fields, nil
 }
 
 func getFieldType(file *ast.File, field ast.Expr, genericParamTypeDefs map[string]*genericTypeSpec) (string, error) {
@@ -1814,6 +2322,10 @@ func (parser *Parser) GetSchemaTypePath(schema *spec.Schema, depth int) []string
 
 // defineTypeOfExample example value define the type (object and array unsupported).
 func defineTypeOfExample(schemaType, arrayType, exampleValue string) (interface{}, error) {
+	if value, ok := parseJSONValue(schemaType, exampleValue); ok {
+		return value, nil
+	}
+
 	switch schemaType {
 	case STRING:
 		return exampleValue, nil
@@ -4507,3 +4507,161 @@ func TestParser_DescriptionLineContinuation(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, string(expected), string(b))
 }
+
+func TestParseMapKeyTypes(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+import "github.com/google/uuid"
+
+type Currency string
+
+const (
+	EUR Currency = "EUR"
+	USD Currency = "USD"
+)
+
+type Stat struct {
+	Count int
+}
+
+type Report struct {
+	Stats    map[int64]Stat
+	Amounts  map[Currency]float64
+	Owners   map[uuid.UUID]string
+	Labels   map[string]string
+	Counts   map[uint8]int ` + "`validate:\"dive,keys,max=10,endkeys\"`" + `
+}
+
+// @Success 200 {object} Report
+// @Success 201 {object} map[int]Stat
+// @Router /report [get]
+func Get() {
+}
+`
+	p := New()
+	err := p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	assert.NoError(t, err)
+
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	report := p.swagger.Definitions["api.Report"]
+
+	keyType := func(name string) *spec.Schema {
+		schema, _ := report.Properties[name].Extensions[mapKeyTypeExtension].(*spec.Schema)
+		return schema
+	}
+
+	assert.Equal(t, spec.StringOrArray{INTEGER}, keyType("stats").Type)
+	assert.Equal(t, "int64", keyType("stats").Format)
+	assert.Equal(t, []interface{}{"EUR", "USD"}, keyType("amounts").Enum)
+	assert.Equal(t, []string{"EUR", "USD"}, keyType("amounts").Extensions[enumVarNamesExtension])
+	assert.Equal(t, "uuid", keyType("owners").Format)
+	assert.Nil(t, keyType("labels"))
+	assert.Equal(t, "int32", keyType("counts").Format)
+	assert.Equal(t, float64(10), *keyType("counts").Maximum)
+
+	response := p.swagger.Paths.Paths["/report"].Get.Responses.StatusCodeResponses[201]
+	assert.Equal(t, spec.StringOrArray{INTEGER}, response.Schema.Extensions[mapKeyTypeExtension].(*spec.Schema).Type)
+}
+
+func TestParseStrictObjects(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// Metadata is stored as is
+// @additionalProperties true
+type Metadata struct {
+	Source string ` + "`json:\"source\"`" + `
+}
+
+type Base struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+type Order struct {
+	Base     ` + "`swaggerembed:\"allOf\"`" + `
+	Metadata Metadata ` + "`json:\"metadata\"`" + `
+	Items    []struct {
+		SKU string ` + "`json:\"sku\"`" + `
+	} ` + "`json:\"items\"`" + `
+	Labels map[string]struct {
+		Color string ` + "`json:\"color\"`" + `
+	} ` + "`json:\"labels\" additionalProperties:\"true\"`" + `
+	Shipping struct {
+		Address string ` + "`json:\"address\"`" + `
+	} ` + "`json:\"shipping\" additionalProperties:\"true\"`" + `
+}
+
+// @Success 200 {object} Order
+// @Router /orders [get]
+func Get() {
+}
+`
+	p := New(SetStrictObjects(true), SetOpenAPIVersion("3.1"))
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+	p.openAllOfMembers()
+
+	// an embedded struct stays open, or it would reject the properties of the struct embedding it
+	base := p.swagger.Definitions["api.Base"]
+	assert.Nil(t, base.AdditionalProperties)
+
+	metadata := p.swagger.Definitions["api.Metadata"]
+	assert.Equal(t, &spec.SchemaOrBool{Allows: true}, metadata.AdditionalProperties)
+
+	order := p.swagger.Definitions["api.Order"]
+	assert.Nil(t, order.AdditionalProperties)
+	assert.Equal(t, false, order.ExtraProps["unevaluatedProperties"])
+	if assert.Len(t, order.AllOf, 2) {
+		own := order.AllOf[1]
+		assert.Nil(t, own.AdditionalProperties)
+		assert.Equal(t, &spec.SchemaOrBool{Allows: false}, own.Properties["items"].Items.Schema.AdditionalProperties)
+		assert.True(t, own.Properties["labels"].AdditionalProperties.Schema.AdditionalProperties.Allows)
+		assert.Equal(t, &spec.SchemaOrBool{Allows: true}, own.Properties["shipping"].AdditionalProperties)
+	}
+
+	assert.NoError(t, validateInstance(p.swagger.Definitions, order,
+		`{"id": 1, "metadata": {"source": "web"}, "items": [{"sku": "A1"}], "shipping": {"address": "Main St"}}`))
+	assert.Error(t, validateInstance(p.swagger.Definitions, order, `{"id": 1, "items": [{"sku": "A1", "count": 2}]}`))
+}
+
+func TestParseStrictObjectsTagError(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Metadata struct {
+	Source string ` + "`json:\"source\"`" + `
+}
+
+type Order struct {
+	Metadata Metadata ` + "`json:\"metadata\" additionalProperties:\"true\"`" + `
+}
+
+// @Success 200 {object} Order
+// @Router /orders [get]
+func Get() {
+}
+`
+	p := New(SetStrictObjects(true))
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.ErrorContains(t, err, "additionalProperties tag applies to inline structs only")
+}
@@ -0,0 +1,251 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	// pathAttr declares the data the operations of a path share, e.g. @Path /items/{id}.
+	pathAttr = "@path"
+	// serverAttr declares a server of the operations of a path, e.g. @Server https://eu.example.com/api "EU".
+	serverAttr = "@server"
+)
+
+// The data of a path item which Swagger 2.0 lacks are kept in extensions of the path item,
+// the OpenAPI 3 document turns them into the fields of the path item.
+const (
+	traceExtension           = "x-trace"
+	pathSummaryExtension     = "x-summary"
+	pathDescriptionExtension = "x-description"
+	pathServersExtension     = "x-servers"
+)
+
+// pathItemAttributes the annotations of a @Path block.
+var pathItemAttributes = map[string]bool{
+	pathAttr:        true,
+	summaryAttr:     true,
+	descriptionAttr: true,
+	paramAttr:       true,
+	serverAttr:      true,
+}
+
+// traceOperation the TRACE operation of a path item, kept in its x-trace extension.
+type traceOperation struct {
+	op *spec.Operation
+}
+
+// MarshalJSON marshals the operation.
+func (trace *traceOperation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(trace.op)
+}
+
+// refTraceOp returns the TRACE operation of a path item, creating its extension if create.
+func refTraceOp(item *spec.PathItem, create bool) **spec.Operation {
+	trace, ok := item.Extensions[traceExtension].(*traceOperation)
+	if !ok {
+		trace = &traceOperation{}
+		if create {
+			item.AddExtension(traceExtension, trace)
+		}
+	}
+
+	return &trace.op
+}
+
+// pathItemServer a server of the operations of a path.
+type pathItemServer struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// ParsePathItemInfo parses the @Path blocks of a file, the doc comment of a type or any comment of doc.go, e.g.
+//
+//	// @Path        /items/{id}
+//	// @Summary     An item
+//	// @Description The item of an id
+//	// @Param       id path int true "Item ID"
+//	// @Server      https://eu.example.com/api "EU"
+//
+// The params of a @Path block are shared by all the operations of the path, instead of being repeated in each of them.
+func (parser *Parser) ParsePathItemInfo(fileInfo *AstFileInfo) error {
+	if (fileInfo.ParseFlag & ParseOperations) == ParseNone {
+		return nil
+	}
+
+	for _, comments := range fileInfo.File.Comments {
+		if !isPathItemDoc(comments) {
+			continue
+		}
+
+		err := parser.parsePathItem(comments, fileInfo.File)
+		if err != nil {
+			return fmt.Errorf("ParseComment error in file %s: %+v", fileInfo.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// isPathItemDoc whether a comment declares a @Path block.
+func isPathItemDoc(comments *ast.CommentGroup) bool {
+	if comments == nil {
+		return false
+	}
+
+	for _, line := range strings.Split(comments.Text(), "\n") {
+		fields := FieldsByAnySpace(strings.TrimSpace(line), 2)
+		if len(fields) > 0 && strings.ToLower(fields[0]) == pathAttr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parsePathItem parses a @Path block into its path item.
+func (parser *Parser) parsePathItem(comments *ast.CommentGroup, file *ast.File) error {
+	var (
+		path    string
+		servers []pathItemServer
+	)
+
+	operation := NewOperation(parser, SetCodeExampleFilesDirectory(parser.codeExampleFilesDir))
+	for _, line := range strings.Split(comments.Text(), "\n") {
+		fields := FieldsByAnySpace(strings.TrimSpace(line), 2)
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "@") {
+			continue
+		}
+
+		attribute := strings.ToLower(fields[0])
+		if !pathItemAttributes[attribute] {
+			return fmt.Errorf("%s is not allowed in %s", fields[0], pathAttr)
+		}
+
+		var value string
+		if len(fields) > 1 {
+			value = strings.TrimSpace(fields[1])
+		}
+
+		switch attribute {
+		case pathAttr:
+			if path != "" {
+				return fmt.Errorf("%s %s declares another path %s", pathAttr, path, value)
+			}
+			if !strings.HasPrefix(value, "/") {
+				return fmt.Errorf("invalid path %q of %s", value, pathAttr)
+			}
+			path = value
+		case serverAttr:
+			server, err := parsePathItemServer(value)
+			if err != nil {
+				return err
+			}
+			servers = append(servers, server)
+		default:
+			err := operation.ParseComment(line, file)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	path, routeParams := routePathParams(path)
+	if parser.pathItems[path] {
+		return fmt.Errorf("%s %s is declared multiple times", pathAttr, path)
+	}
+
+	for _, param := range operation.Parameters {
+		switch param.In {
+		case "body", "formData":
+			return fmt.Errorf("%s param %s of %s %s can not be shared by its operations", param.In, param.Name, pathAttr, path)
+		case "path":
+			if hasRouteParam(routeParams, param.Name) {
+				continue
+			}
+
+			err := fmt.Errorf("path param %s is not in route %s", param.Name, path)
+			if parser.Strict {
+				return err
+			}
+
+			parser.debug.Printf("warning: %s", err)
+		}
+	}
+
+	pathItem := parser.swagger.Paths.Paths[path]
+	pathItem.Parameters = addRouteParams(parser, operation.Parameters, routeParams)
+	if operation.Summary != "" {
+		pathItem.AddExtension(pathSummaryExtension, operation.Summary)
+	}
+	if operation.Description != "" {
+		pathItem.AddExtension(pathDescriptionExtension, operation.Description)
+	}
+	if len(servers) > 0 {
+		pathItem.AddExtension(pathServersExtension, servers)
+	}
+	parser.swagger.Paths.Paths[path] = pathItem
+
+	if parser.pathItems == nil {
+		parser.pathItems = make(map[string]bool)
+	}
+	parser.pathItems[path] = true
+
+	return nil
+}
+
+// parsePathItemServer parses the url and the optional description of @Server.
+func parsePathItemServer(value string) (pathItemServer, error) {
+	fields := FieldsByAnySpace(value, 2)
+	if len(fields) == 0 {
+		return pathItemServer{}, fmt.Errorf("%s needs a url", serverAttr)
+	}
+
+	server := pathItemServer{URL: fields[0]}
+	if len(fields) > 1 {
+		server.Description = strings.Trim(strings.TrimSpace(fields[1]), `"`)
+	}
+
+	return server, nil
+}
+
+// hoistPathItemParams removes the params of an operation which its path item declares for all its operations,
+// and the route params the path item declares, which are not added to the operation then.
+// A param of the operation differing from the one of the path item overrides it.
+func hoistPathItemParams(pathItem *spec.PathItem, params []spec.Parameter, routeParams []routeParam) ([]spec.Parameter, []routeParam) {
+	if len(pathItem.Parameters) == 0 {
+		return params, routeParams
+	}
+
+	var operationParams []spec.Parameter
+	for _, param := range params {
+		if !containsParam(pathItem.Parameters, param) {
+			operationParams = append(operationParams, param)
+		}
+	}
+
+	var undeclared []routeParam
+	for _, routeParam := range routeParams {
+		if !hasParam(pathItem.Parameters, routeParam.name, "path") {
+			undeclared = append(undeclared, routeParam)
+		}
+	}
+
+	return operationParams, undeclared
+}
+
+// containsParam whether params contain a param equal to param.
+func containsParam(params []spec.Parameter, param spec.Parameter) bool {
+	for _, p := range params {
+		if reflect.DeepEqual(p, param) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePathItems(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// Item is an item.
+//
+// @Path        /items/{id:[0-9]+}
+// @Summary     An item
+// @Description The item of an id
+// @Param       id path int true "Item ID"
+// @Server      https://eu.example.com/api "EU"
+type Item struct {
+	Name string
+}
+
+// @Summary Show an item
+// @Param   id path int true "Item ID"
+// @Success 200 {string} string
+// @Router  /items/{id:[0-9]+} [get]
+func ShowItem() {
+}
+
+// @Summary Delete an item
+// @Success 204
+// @Router  /items/{id} [delete]
+func DeleteItem() {
+}
+
+// @Summary Trace an item
+// @Success 200 {string} string
+// @Router  /items/{id} [trace]
+func TraceItem() {
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+	assert.NoError(t, p.packages.RangeFiles(p.ParsePathItemInfo))
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	expected := `{
+   "/items/{id}": {
+      "delete": {
+         "summary": "Delete an item",
+         "responses": {
+            "204": {
+               "description": "No Content"
+            }
+         }
+      },
+      "get": {
+         "summary": "Show an item",
+         "responses": {
+            "200": {
+               "description": "OK",
+               "schema": {
+                  "type": "string"
+               }
+            }
+         }
+      },
+      "parameters": [
+         {
+            "type": "integer",
+            "description": "Item ID",
+            "name": "id",
+            "in": "path",
+            "required": true
+         }
+      ],
+      "x-description": "The item of an id",
+      "x-servers": [
+         {
+            "url": "https://eu.example.com/api",
+            "description": "EU"
+         }
+      ],
+      "x-summary": "An item",
+      "x-trace": {
+         "summary": "Trace an item",
+         "responses": {
+            "200": {
+               "description": "OK",
+               "schema": {
+                  "type": "string"
+               }
+            }
+         }
+      }
+   }
+}`
+	b, err := json.MarshalIndent(p.swagger.Paths.Paths, "", "   ")
+	assert.NoError(t, err)
+	assert.JSONEq(t, expected, string(b))
+
+	item := p.swagger.Paths.Paths["/items/{id}"]
+	assert.Equal(t, "Trace an item", (*refRouteMethodOp(&item, "TRACE")).Summary)
+}
+
+func TestParsePathItemsError(t *testing.T) {
+	t.Parallel()
+
+	for src, expected := range map[string]string{
+		"// @Path /items\n// @Param item body string true \"item\"\ntype A struct{}": "body param item of @path /items can not be shared by its operations",
+		"// @Path /items\n// @Router /items [get]\ntype A struct{}":                  "@Router is not allowed in @path",
+		"// @Path /items\ntype A struct{}\n\n// @Path /items\ntype B struct{}":       "@path /items is declared multiple times",
+	} {
+		p := New()
+		assert.NoError(t, p.packages.ParseFile("api", "api/api.go", "package api\n\n"+src, ParseAll))
+		err := p.packages.RangeFiles(p.ParsePathItemInfo)
+		assert.ErrorContains(t, err, expected)
+	}
+}
@@ -0,0 +1,201 @@
+package swag
+
+import (
+	"go/ast"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	protobufTag      = "protobuf"
+	protobufOneofTag = "protobuf_oneof"
+
+	// protobufInternalPrefix the prefix of the internal fields generated by older protobuf and gogo/protobuf versions
+	protobufInternalPrefix = "XXX_"
+)
+
+// protobufKnownTypesPath the path of the packages of the well-known types of protobuf.
+const protobufKnownTypesPath = "google.golang.org/protobuf/types/known/"
+
+// protobufWellKnownTypes maps the well-known types of protobuf, by the path of their package,
+// to the schemas of their canonical JSON encoding (protojson), built anew for each use.
+var protobufWellKnownTypes = map[string]func() *spec.Schema{
+	protobufKnownTypesPath + "timestamppb.Timestamp": func() *spec.Schema { return spec.DateTimeProperty() },
+	protobufKnownTypesPath + "durationpb.Duration":   func() *spec.Schema { return spec.StringProperty().WithPattern(`^-?[0-9]+(\.[0-9]{1,9})?s$`) },
+	protobufKnownTypesPath + "structpb.Struct":       func() *spec.Schema { return spec.MapProperty(nil) },
+	protobufKnownTypesPath + "structpb.Value":        func() *spec.Schema { return &spec.Schema{} },
+	protobufKnownTypesPath + "structpb.ListValue":    func() *spec.Schema { return spec.ArrayProperty(&spec.Schema{}) },
+	protobufKnownTypesPath + "emptypb.Empty":         func() *spec.Schema { return PrimitiveSchema(OBJECT) },
+	protobufKnownTypesPath + "fieldmaskpb.FieldMask": func() *spec.Schema { return spec.StringProperty() },
+	protobufKnownTypesPath + "anypb.Any": func() *spec.Schema {
+		return PrimitiveSchema(OBJECT).
+			SetProperty("@type", *spec.StringProperty()).
+			WithRequired("@type")
+	},
+	protobufKnownTypesPath + "wrapperspb.DoubleValue": func() *spec.Schema { return TransToValidPrimitiveSchema("float64") },
+	protobufKnownTypesPath + "wrapperspb.FloatValue":  func() *spec.Schema { return TransToValidPrimitiveSchema("float32") },
+	protobufKnownTypesPath + "wrapperspb.Int64Value":  func() *spec.Schema { return spec.StrFmtProperty("int64") },
+	protobufKnownTypesPath + "wrapperspb.UInt64Value": func() *spec.Schema { return spec.StrFmtProperty("uint64") },
+	protobufKnownTypesPath + "wrapperspb.Int32Value":  func() *spec.Schema { return TransToValidPrimitiveSchema("int32") },
+	protobufKnownTypesPath + "wrapperspb.UInt32Value": func() *spec.Schema { return TransToValidPrimitiveSchema("uint32") },
+	protobufKnownTypesPath + "wrapperspb.BoolValue":   func() *spec.Schema { return TransToValidPrimitiveSchema("bool") },
+	protobufKnownTypesPath + "wrapperspb.StringValue": func() *spec.Schema { return TransToValidPrimitiveSchema("string") },
+	protobufKnownTypesPath + "wrapperspb.BytesValue":  func() *spec.Schema { return spec.StrFmtProperty("byte") },
+}
+
+// protobufOneofWrapperRegexp matches the wrapper types listed in the comment protoc-gen-go writes above a oneof field.
+var protobufOneofWrapperRegexp = regexp.MustCompile(`^\s*\*(\w+)\s*$`)
+
+// protobufWellKnownSchema returns the schema of a well-known protobuf type, resolving the name a file imports its package by,
+// e.g. timestamppb.Timestamp, or ts.Timestamp for an import named ts.
+func protobufWellKnownSchema(typeName string, file *ast.File) (*spec.Schema, bool) {
+	pkgName, name, ok := strings.Cut(typeName, ".")
+	if !ok || file == nil {
+		return nil, false
+	}
+
+	pkgPath := importPath(file, pkgName)
+	if pkgPath == "" {
+		return nil, false
+	}
+
+	schema, ok := protobufWellKnownTypes[pkgPath+"."+name]
+	if !ok {
+		return nil, false
+	}
+
+	return schema(), true
+}
+
+// protobufJSONName returns the json name a protobuf tag implies,
+// e.g. created_at for `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3"` is createdAt.
+func protobufJSONName(tag string) string {
+	var name string
+	for _, option := range strings.Split(tag, ",") {
+		key, value, ok := strings.Cut(option, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "json":
+			return value
+		case "name":
+			name = value
+		}
+	}
+
+	return name
+}
+
+// complementProtobufSchema restores the encoding of protojson after the field tags are applied:
+// 64-bit integers are decimal strings and well-known types keep the format and pattern of their canonical JSON.
+func complementProtobufSchema(file *ast.File, fieldType ast.Expr, schema *spec.Schema) {
+	if star, ok := fieldType.(*ast.StarExpr); ok {
+		fieldType = star.X
+	}
+	if array, ok := fieldType.(*ast.ArrayType); ok && schema.Items != nil && schema.Items.Schema != nil {
+		fieldType, schema = array.Elt, schema.Items.Schema
+	}
+
+	if ident, ok := fieldType.(*ast.Ident); ok && (ident.Name == "int64" || ident.Name == "uint64") {
+		schema.Type = []string{STRING}
+		if schema.Format == "" {
+			schema.Format = ident.Name
+		}
+
+		return
+	}
+
+	typeName, err := getFieldType(file, fieldType, nil)
+	if err != nil {
+		return
+	}
+	if wellKnown, ok := protobufWellKnownSchema(typeName, file); ok {
+		if schema.Format == "" {
+			schema.Format = wellKnown.Format
+		}
+		if schema.Pattern == "" {
+			schema.Pattern = wellKnown.Pattern
+		}
+	}
+}
+
+// parseProtobufOneof parses a oneof field of a protobuf message, such as
+//
+//	// Types that are valid to be assigned to Payment:
+//	//
+//	//	*Order_Card
+//	//	*Order_Cash
+//	Payment isOrder_Payment `protobuf_oneof:"payment"`
+//
+// as the optional properties of its wrapper types, which protojson writes into the message itself.
+// A oneof may be unset, so at most one of the properties is present: the returned schema has the properties
+// and, in its allOf, a not required pair for each two properties of different wrapper types.
+func (parser *Parser) parseProtobufOneof(file *ast.File, field *ast.Field) (*spec.Schema, error) {
+	if field.Tag == nil {
+		return nil, nil
+	}
+
+	tag := reflect.StructTag(strings.ReplaceAll(field.Tag.Value, "`", ""))
+	oneofName, ok := tag.Lookup(protobufOneofTag)
+	if !ok {
+		return nil, nil
+	}
+
+	var variants [][]string
+	properties := make(map[string]spec.Schema)
+	if field.Doc != nil {
+		for _, comment := range field.Doc.List {
+			matches := protobufOneofWrapperRegexp.FindStringSubmatch(strings.TrimPrefix(comment.Text, "//"))
+			if len(matches) != 2 {
+				continue
+			}
+
+			schema, err := parser.getTypeSchema(matches[1], file, false)
+			if err != nil {
+				return nil, err
+			}
+
+			var names []string
+			for name, property := range schema.Properties {
+				properties[name] = property
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			variants = append(variants, names)
+		}
+	}
+
+	if len(variants) == 0 {
+		parser.debug.Printf("Could not find the wrapper types of oneof %s, skipping it", oneofName)
+
+		return nil, ErrSkippedField
+	}
+
+	var exclusions []spec.Schema
+	for i := range variants {
+		for j := i + 1; j < len(variants); j++ {
+			for _, a := range variants[i] {
+				for _, b := range variants[j] {
+					exclusions = append(exclusions, spec.Schema{
+						SchemaProps: spec.SchemaProps{
+							Not: &spec.Schema{SchemaProps: spec.SchemaProps{Required: []string{a, b}}},
+						},
+					})
+				}
+			}
+		}
+	}
+
+	return &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Properties: properties,
+			AllOf:      exclusions,
+		},
+	}, nil
+}
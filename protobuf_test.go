@@ -0,0 +1,212 @@
+package swag
+
+import (
+	"encoding/json"
+	"go/ast"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProtobuf(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package pb
+
+import (
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	ts "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type Order struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId   string                  ` + "`protobuf:\"bytes,1,opt,name=order_id,json=orderId,proto3\" json:\"order_id,omitempty\"`" + `
+	Amount    int64                   ` + "`protobuf:\"varint,2,opt,name=amount,proto3\" json:\"amount,omitempty\"`" + `
+	Quantities []int64                ` + "`protobuf:\"varint,3,rep,packed,name=quantities,proto3\" json:\"quantities,omitempty\"`" + `
+	CreatedAt *ts.Timestamp           ` + "`protobuf:\"bytes,4,opt,name=created_at,json=createdAt,proto3\" json:\"created_at,omitempty\"`" + `
+	Ttl       *durationpb.Duration    ` + "`protobuf:\"bytes,5,opt,name=ttl,proto3\" json:\"ttl,omitempty\"`" + `
+	Metadata  *structpb.Struct        ` + "`protobuf:\"bytes,6,opt,name=metadata,proto3\" json:\"metadata,omitempty\"`" + `
+	Note      *wrapperspb.StringValue ` + "`protobuf:\"bytes,7,opt,name=note,proto3\" json:\"note,omitempty\"`" + `
+	Views     *wrapperspb.UInt64Value ` + "`protobuf:\"bytes,10,opt,name=views,proto3\" json:\"views,omitempty\"`" + `
+	Total     uint64                  ` + "`protobuf:\"varint,11,opt,name=total,proto3\" json:\"total,omitempty\"`" + `
+	// Types that are valid to be assigned to Payment:
+	//
+	//	*Order_Card
+	//	*Order_Cash
+	Payment isOrder_Payment ` + "`protobuf_oneof:\"payment\"`" + `
+
+	XXX_unrecognized []byte ` + "`json:\"xxx_unrecognized\"`" + `
+}
+
+type isOrder_Payment interface {
+	isOrder_Payment()
+}
+
+type Order_Card struct {
+	CardNumber string ` + "`protobuf:\"bytes,8,opt,name=card_number,json=cardNumber,proto3,oneof\"`" + `
+}
+
+type Order_Cash struct {
+	Cash bool ` + "`protobuf:\"varint,9,opt,name=cash,proto3,oneof\"`" + `
+}
+
+func (*Order_Card) isOrder_Payment() {}
+
+func (*Order_Cash) isOrder_Payment() {}
+
+// @Success 200 {object} Order
+// @Router /order [get]
+func Get() {
+}
+`
+	expected := `{
+   "type": "object",
+   "not": {
+      "required": [
+         "cardNumber",
+         "cash"
+      ]
+   },
+   "properties": {
+      "amount": {
+         "type": "string",
+         "format": "int64"
+      },
+      "cardNumber": {
+         "type": "string"
+      },
+      "cash": {
+         "type": "boolean"
+      },
+      "createdAt": {
+         "type": "string",
+         "format": "date-time"
+      },
+      "metadata": {
+         "type": "object",
+         "additionalProperties": true
+      },
+      "note": {
+         "type": "string"
+      },
+      "orderId": {
+         "type": "string"
+      },
+      "quantities": {
+         "type": "array",
+         "items": {
+            "type": "string",
+            "format": "int64"
+         }
+      },
+      "total": {
+         "type": "string",
+         "format": "uint64"
+      },
+      "ttl": {
+         "type": "string",
+         "pattern": "^-?[0-9]+(\\.[0-9]{1,9})?s$"
+      },
+      "views": {
+         "type": "string",
+         "format": "uint64"
+      }
+   }
+}`
+
+	p := New(SetParseProtobuf(true))
+	err := p.packages.ParseFile("pb", "pb/order.pb.go", src, ParseAll)
+	assert.NoError(t, err)
+
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	out, err := json.MarshalIndent(p.swagger.Definitions["pb.Order"], "", "   ")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(out))
+
+	// the oneof may be unset, or set to one of its fields
+	order := p.swagger.Definitions["pb.Order"]
+	assert.NoError(t, validateInstance(p.swagger.Definitions, order, `{"orderId": "1"}`))
+	assert.NoError(t, validateInstance(p.swagger.Definitions, order, `{"orderId": "1", "cash": true}`))
+	assert.Error(t, validateInstance(p.swagger.Definitions, order, `{"cardNumber": "4242", "cash": true}`))
+}
+
+func TestParseProtobufOneofEmbedded(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package pb
+
+import anypb "google.golang.org/protobuf/types/known/anypb"
+
+type Base struct {
+	Id string ` + "`protobuf:\"bytes,1,opt,name=id,proto3\" json:\"id,omitempty\"`" + `
+}
+
+type Event struct {
+	Base ` + "`swaggerembed:\"allOf\"`" + `
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Event_Text
+	//	*Event_Detail
+	Payload isEvent_Payload ` + "`protobuf_oneof:\"payload\"`" + `
+}
+
+type isEvent_Payload interface {
+	isEvent_Payload()
+}
+
+type Event_Text struct {
+	Text string ` + "`protobuf:\"bytes,2,opt,name=text,proto3,oneof\"`" + `
+}
+
+type Event_Detail struct {
+	Detail *anypb.Any ` + "`protobuf:\"bytes,3,opt,name=detail,proto3,oneof\"`" + `
+}
+
+// @Success 200 {object} Event
+// @Router /event [get]
+func Get() {
+}
+`
+	p := New(SetParseProtobuf(true))
+	err := p.packages.ParseFile("pb", "pb/event.pb.go", src, ParseAll)
+	assert.NoError(t, err)
+
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	event := p.swagger.Definitions["pb.Event"]
+	if assert.Len(t, event.AllOf, 2) {
+		assert.Equal(t, []string{"text", "detail"}, event.AllOf[1].Not.Required)
+		assert.Contains(t, event.AllOf[1].Properties, "detail")
+	}
+	assert.NoError(t, validateInstance(p.swagger.Definitions, event, `{"id": "1", "text": "hello"}`))
+	assert.Error(t, validateInstance(p.swagger.Definitions, event, `{"text": "hello", "detail": {"@type": "x"}}`))
+
+	// the schemas of the well-known types are not shared
+	detail := event.AllOf[1].Properties["detail"]
+	detail.Properties["@type"] = *spec.Int64Property()
+	var file *ast.File
+	for f := range p.packages.files {
+		file = f
+	}
+	schema, ok := protobufWellKnownSchema("anypb.Any", file)
+	assert.True(t, ok)
+	assert.Equal(t, spec.StringOrArray{STRING}, schema.Properties["@type"].Type)
+}
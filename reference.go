@@ -0,0 +1,385 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	// constReferencePrefix refers an example or a default to a constant, e.g. const:DefaultPageSize or const:model.DefaultLimit.
+	constReferencePrefix = "const:"
+	// varReferencePrefix refers an example to a package-level variable, e.g. var:fixtures.SampleUser.
+	varReferencePrefix = "var:"
+)
+
+// responseExampleRegexp matches the example attribute of a response, e.g. example(var:fixtures.SampleUser).
+var responseExampleRegexp = regexp.MustCompile(`(?i)\s+example\(((?:const|var):[\w.]+)\)`)
+
+// isValueReference whether the value of an example or a default refers to a constant or a variable.
+func isValueReference(value string) bool {
+	return strings.HasPrefix(value, constReferencePrefix) || strings.HasPrefix(value, varReferencePrefix)
+}
+
+// evaluateValueReference evaluates a reference to a constant or a package-level variable into the value JSON encodes,
+// a variable initialized by a composite literal becomes an object or an array.
+func (parser *Parser) evaluateValueReference(file *ast.File, ref string) (interface{}, error) {
+	kind, name, _ := strings.Cut(ref, ":")
+
+	var pkgName string
+	if pos := strings.LastIndexByte(name, '.'); pos >= 0 {
+		pkgName, name = name[:pos], name[pos+1:]
+	}
+
+	switch kind + ":" {
+	case constReferencePrefix:
+		value, _ := parser.packages.EvaluateConstValueByName(file, pkgName, name, nil)
+		if value == nil {
+			return nil, fmt.Errorf("could not evaluate const %s", strings.TrimPrefix(ref, constReferencePrefix))
+		}
+
+		return value, nil
+	case varReferencePrefix:
+		variable := parser.packages.FindVariable(file, pkgName, name)
+		if variable == nil {
+			return nil, fmt.Errorf("could not find var %s", strings.TrimPrefix(ref, varReferencePrefix))
+		}
+
+		value, err := parser.evaluateLiteral(variable.File, variable.Type, variable.Value.(ast.Expr))
+		if err != nil {
+			return nil, fmt.Errorf("could not evaluate var %s: %w", strings.TrimPrefix(ref, varReferencePrefix), err)
+		}
+
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("invalid reference %s", ref)
+}
+
+// evaluateLiteral evaluates the expression a variable is initialized with, typ is the type the expression is assigned to,
+// which the elements of composite literals omit.
+func (parser *Parser) evaluateLiteral(file *ast.File, typ ast.Expr, expr ast.Expr) (interface{}, error) {
+	switch valueExpr := expr.(type) {
+	case *ast.ParenExpr:
+		return parser.evaluateLiteral(file, typ, valueExpr.X)
+	case *ast.UnaryExpr:
+		if valueExpr.Op == token.AND {
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+
+			return parser.evaluateLiteral(file, typ, valueExpr.X)
+		}
+	case *ast.CompositeLit:
+		if valueExpr.Type != nil {
+			typ = valueExpr.Type
+		}
+
+		return parser.evaluateCompositeLiteral(file, typ, valueExpr)
+	case *ast.Ident:
+		switch valueExpr.Name {
+		case "nil":
+			return nil, nil
+		case "true", "false":
+			return valueExpr.Name == "true", nil
+		}
+
+		if value, err := parser.evaluateValueReference(file, varReferencePrefix+valueExpr.Name); err == nil {
+			return value, nil
+		}
+	case *ast.SelectorExpr:
+		if pkgIdent, ok := valueExpr.X.(*ast.Ident); ok {
+			if variable := parser.packages.FindVariable(file, pkgIdent.Name, valueExpr.Sel.Name); variable != nil {
+				return parser.evaluateLiteral(variable.File, variable.Type, variable.Value.(ast.Expr))
+			}
+		}
+	}
+
+	fileInfo, ok := parser.packages.files[file]
+	if !ok {
+		return nil, fmt.Errorf("unknown file of expression %T", expr)
+	}
+
+	pkg := parser.packages.packages[fileInfo.PackagePath]
+	if pkg == nil {
+		return nil, fmt.Errorf("unknown package %s of expression %s", fileInfo.PackagePath, types.ExprString(expr))
+	}
+
+	if unsupported := unsupportedConstExpr(expr); unsupported == expr {
+		return nil, fmt.Errorf("%s is not a constant expression", types.ExprString(expr))
+	} else if unsupported != nil {
+		return nil, fmt.Errorf("could not evaluate %s: %s is not a constant expression", types.ExprString(expr), types.ExprString(unsupported))
+	}
+
+	value, _ := pkg.evaluateConstValue(file, 0, expr, parser.packages, nil)
+	if value == nil {
+		return nil, fmt.Errorf("could not evaluate %s", types.ExprString(expr))
+	}
+
+	return value, nil
+}
+
+// unsupportedConstExpr returns the part of an expression which can not be evaluated as a constant,
+// such as a function call, or nil if there is none. Conversions to primitive types are evaluated.
+func unsupportedConstExpr(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return nil
+	case *ast.SelectorExpr:
+		if _, ok := e.X.(*ast.Ident); ok {
+			return nil
+		}
+	case *ast.ParenExpr:
+		return unsupportedConstExpr(e.X)
+	case *ast.UnaryExpr:
+		return unsupportedConstExpr(e.X)
+	case *ast.BinaryExpr:
+		if unsupported := unsupportedConstExpr(e.X); unsupported != nil {
+			return unsupported
+		}
+
+		return unsupportedConstExpr(e.Y)
+	case *ast.CallExpr:
+		if ident, ok := e.Fun.(*ast.Ident); ok && len(e.Args) == 1 && IsGolangPrimitiveType(ident.Name) {
+			return unsupportedConstExpr(e.Args[0])
+		}
+	}
+
+	return expr
+}
+
+// evaluateCompositeLiteral evaluates a struct literal into an object named as its JSON encoding,
+// a slice or array literal into an array and a map literal into an object.
+func (parser *Parser) evaluateCompositeLiteral(file *ast.File, typ ast.Expr, lit *ast.CompositeLit) (interface{}, error) {
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+
+			continue
+		case *ast.Ident, *ast.SelectorExpr:
+			typeName, err := getFieldType(file, t, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			typeSpecDef := parser.packages.FindTypeSpec(typeName, file)
+			if typeSpecDef == nil {
+				return nil, fmt.Errorf("cannot find type definition: %s", typeName)
+			}
+
+			file, typ = typeSpecDef.File, typeSpecDef.TypeSpec.Type
+
+			continue
+		case *ast.StructType:
+			return parser.evaluateStructLiteral(file, t, lit)
+		case *ast.ArrayType:
+			values := make([]interface{}, 0, len(lit.Elts))
+			for _, elt := range lit.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					elt = kv.Value
+				}
+
+				value, err := parser.evaluateLiteral(file, t.Elt, elt)
+				if err != nil {
+					return nil, err
+				}
+
+				values = append(values, value)
+			}
+
+			return values, nil
+		case *ast.MapType:
+			values := make(map[string]interface{}, len(lit.Elts))
+			for _, elt := range lit.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+
+				key, err := parser.evaluateLiteral(file, t.Key, kv.Key)
+				if err != nil {
+					return nil, err
+				}
+
+				value, err := parser.evaluateLiteral(file, t.Value, kv.Value)
+				if err != nil {
+					return nil, err
+				}
+
+				values[fmt.Sprint(key)] = value
+			}
+
+			return values, nil
+		}
+
+		return nil, fmt.Errorf("composite literal of type %s is not supported", types.ExprString(typ))
+	}
+}
+
+// evaluateStructLiteral evaluates a struct literal into an object with the properties the struct is documented with,
+// the fields of an embedded struct are promoted unless the fields of the struct itself shadow them.
+func (parser *Parser) evaluateStructLiteral(file *ast.File, structType *ast.StructType, lit *ast.CompositeLit) (interface{}, error) {
+	type literalField struct {
+		field *ast.Field
+		name  string
+	}
+
+	var fields []literalField
+	byName := make(map[string]literalField)
+	for _, field := range structType.Fields.List {
+		var names []string
+		if len(field.Names) == 0 {
+			typeName, err := getFieldType(file, field.Type, nil)
+			if err != nil {
+				return nil, err
+			}
+			names = []string{typeName[strings.LastIndexByte(typeName, '.')+1:]}
+		}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+
+		for _, name := range names {
+			fields = append(fields, literalField{field: field, name: name})
+			byName[name] = literalField{field: field, name: name}
+		}
+	}
+
+	object := make(map[string]interface{})
+	var promoted []map[string]interface{}
+	for i, elt := range lit.Elts {
+		var target literalField
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			key, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				return nil, fmt.Errorf("invalid key %s of struct literal", types.ExprString(kv.Key))
+			}
+			if target, ok = byName[key.Name]; !ok {
+				return nil, fmt.Errorf("unknown field %s in struct literal", key.Name)
+			}
+			elt = kv.Value
+		} else if i < len(fields) {
+			target = fields[i]
+		}
+
+		if target.field == nil {
+			return nil, fmt.Errorf("too many values in struct literal")
+		}
+
+		ps := parser.fieldParserFactory(parser, target.field)
+		if ps.ShouldSkip() {
+			continue
+		}
+
+		value, err := parser.evaluateLiteral(file, target.field.Type, elt)
+		if err != nil {
+			if parser.Strict {
+				return nil, fmt.Errorf("%s: %w", target.name, err)
+			}
+
+			parser.debug.Printf("warning: %s: %s, omitting it from the example", target.name, err)
+
+			continue
+		}
+
+		names, err := ps.FieldNames()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(names) == 0 {
+			// an embedded struct without a name, its properties are promoted
+			if embedded, ok := value.(map[string]interface{}); ok {
+				promoted = append(promoted, embedded)
+			}
+
+			continue
+		}
+
+		if len(target.field.Names) > 1 {
+			for j, name := range target.field.Names {
+				if name.Name == target.name && j < len(names) {
+					object[names[j]] = value
+				}
+			}
+
+			continue
+		}
+
+		object[names[0]] = value
+	}
+
+	for _, embedded := range promoted {
+		for name, value := range embedded {
+			if _, ok := object[name]; !ok {
+				object[name] = value
+			}
+		}
+	}
+
+	return object, nil
+}
+
+// complementValueReferences replaces the examples and defaults of a struct field which refer to a constant or a variable,
+// as example:"const:DefaultPageSize" or default:"const:model.DefaultLimit", by their values.
+func (parser *Parser) complementValueReferences(file *ast.File, ps FieldParser, schema *spec.Schema) error {
+	for _, tag := range []string{exampleTag, defaultTag} {
+		ref := ps.FirstTagValue(tag)
+		if !isValueReference(ref) {
+			continue
+		}
+
+		value, err := parser.evaluateValueReference(file, ref)
+		if err != nil {
+			return err
+		}
+
+		if IsRefSchema(schema) {
+			*schema = *(&spec.Schema{}).WithAllOf(*schema)
+		}
+
+		switch tag {
+		case exampleTag:
+			schema.Example = value
+		case defaultTag:
+			schema.Default = value
+		}
+	}
+
+	return nil
+}
+
+// parseParamValueReferences sets the default and example of a parameter which refer to a constant or a variable,
+// as default(const:model.DefaultLimit).
+func (operation *Operation) parseParamValueReferences(commentLine string, astFile *ast.File, param *spec.Parameter) error {
+	for _, attrKey := range []string{defaultTag, exampleTag, schemaExampleTag} {
+		attr, err := findAttr(regexAttributes[attrKey], commentLine)
+		if err != nil || !isValueReference(attr) {
+			continue
+		}
+
+		value, err := operation.parser.evaluateValueReference(astFile, attr)
+		if err != nil {
+			return err
+		}
+
+		switch attrKey {
+		case defaultTag:
+			param.Default = value
+		case exampleTag:
+			param.Example = value
+		case schemaExampleTag:
+			if param.Schema != nil {
+				param.Schema.Example = value
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package swag
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseValueReferences(t *testing.T) {
+	t.Parallel()
+
+	model := `
+package model
+
+const (
+	DefaultLimit = 20
+	DefaultSort  = "name"
+)
+
+type Status string
+
+const StatusActive Status = "active"
+
+type Base struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+type User struct {
+	Base
+	Name    string            ` + "`json:\"name\"`" + `
+	Status  Status            ` + "`json:\"status\"`" + `
+	Tags    []string          ` + "`json:\"tags\"`" + `
+	Labels  map[string]string ` + "`json:\"labels\"`" + `
+	Friends []*User           ` + "`json:\"friends,omitempty\"`" + `
+	secret  string
+}
+`
+	fixtures := `
+package fixtures
+
+import "example.com/svc/model"
+
+var SampleUser = &model.User{
+	Base:   model.Base{ID: 1},
+	Name:   "Gopher",
+	Status: model.StatusActive,
+	Tags:   []string{"admin", model.DefaultSort},
+	Labels: map[string]string{"team": "core"},
+	Friends: []*model.User{
+		{Name: "Ferris"},
+	},
+}
+`
+	api := `
+package api
+
+import (
+	"example.com/svc/fixtures"
+	"example.com/svc/model"
+)
+
+const DefaultPageSize = 50
+
+type Query struct {
+	PageSize int    ` + "`json:\"pageSize\" example:\"const:DefaultPageSize\" default:\"const:model.DefaultLimit\"`" + `
+	Sort     string ` + "`json:\"sort\" default:\"const:model.DefaultSort\"`" + `
+	Owner    model.User ` + "`json:\"owner\" example:\"var:fixtures.SampleUser\"`" + `
+}
+
+// @Param limit query int false "limit" default(const:model.DefaultLimit) example(const:DefaultPageSize)
+// @Param query body Query true "query"
+// @Success 200 {object} model.User example(var:fixtures.SampleUser) "the user"
+// @Router /users [get]
+func Get() {
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("example.com/svc/model", "model/model.go", model, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("example.com/svc/fixtures", "fixtures/fixtures.go", fixtures, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("example.com/svc/api", "api/api.go", api, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	sampleUser := `{
+   "friends": [
+      {
+         "name": "Ferris"
+      }
+   ],
+   "id": 1,
+   "labels": {
+      "team": "core"
+   },
+   "name": "Gopher",
+   "status": "active",
+   "tags": [
+      "admin",
+      "name"
+   ]
+}`
+
+	query := p.swagger.Definitions["api.Query"]
+	assert.Equal(t, 50, query.Properties["pageSize"].Example)
+	assert.Equal(t, 20, query.Properties["pageSize"].Default)
+	assert.Equal(t, "name", query.Properties["sort"].Default)
+
+	owner := query.Properties["owner"]
+	assert.Len(t, owner.AllOf, 1)
+	b, _ := json.MarshalIndent(owner.Example, "", "   ")
+	assert.Equal(t, sampleUser, string(b))
+
+	operation := p.swagger.Paths.Paths["/users"].Get
+	assert.Equal(t, 20, operation.Parameters[0].Default)
+	assert.Equal(t, 50, operation.Parameters[0].Example)
+
+	response := operation.Responses.StatusCodeResponses[200]
+	assert.Equal(t, "the user", response.Description)
+	assert.Equal(t, "#/definitions/model.User", response.Schema.Ref.String())
+	b, _ = json.MarshalIndent(response.Examples["application/json"], "", "   ")
+	assert.Equal(t, sampleUser, string(b))
+}
+
+func TestEvaluateValueReferenceError(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+import "time"
+
+type Event struct {
+	Name string    ` + "`json:\"name\"`" + `
+	At   time.Time ` + "`json:\"at\"`" + `
+}
+
+var SampleEvent = Event{Name: "launch", At: time.Now()}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	var file *ast.File
+	for f := range p.packages.files {
+		file = f
+	}
+
+	value, err := p.evaluateValueReference(file, "var:SampleEvent")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "launch"}, value)
+
+	_, err = p.evaluateValueReference(file, "var:Missing")
+	assert.EqualError(t, err, "could not find var Missing")
+
+	_, err = p.evaluateValueReference(file, "const:Missing")
+	assert.EqualError(t, err, "could not evaluate const Missing")
+
+	p.Strict = true
+	_, err = p.evaluateValueReference(file, "var:SampleEvent")
+	assert.EqualError(t, err, "could not evaluate var SampleEvent: At: time.Now() is not a constant expression")
+
+	_, err = p.evaluateLiteral(file, nil, &ast.BinaryExpr{
+		X:  &ast.BasicLit{Kind: token.INT, Value: "2"},
+		Op: token.MUL,
+		Y:  &ast.CallExpr{Fun: &ast.Ident{Name: "len"}, Args: []ast.Expr{&ast.Ident{Name: "names"}}},
+	})
+	assert.EqualError(t, err, "could not evaluate 2 * len(names): len(names) is not a constant expression")
+}
@@ -0,0 +1,1104 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"net/http"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// routeRegistrars maps the methods registering a route of a gin, echo or chi router to the HTTP method they register,
+// e.g. r.GET("/accounts/:id", c.ShowAccount) or r.Get("/accounts/{id}", showAccount).
+var routeRegistrars = map[string]string{
+	"GET":     http.MethodGet,
+	"Get":     http.MethodGet,
+	"POST":    http.MethodPost,
+	"Post":    http.MethodPost,
+	"PUT":     http.MethodPut,
+	"Put":     http.MethodPut,
+	"PATCH":   http.MethodPatch,
+	"Patch":   http.MethodPatch,
+	"DELETE":  http.MethodDelete,
+	"Delete":  http.MethodDelete,
+	"HEAD":    http.MethodHead,
+	"Head":    http.MethodHead,
+	"OPTIONS": http.MethodOptions,
+	"Options": http.MethodOptions,
+	"TRACE":   http.MethodTrace,
+	"Trace":   http.MethodTrace,
+}
+
+// routeChainMethods the methods of a gorilla/mux route, which are chained to register it,
+// e.g. r.HandleFunc("/items/{id}", getItem).Methods("GET").
+var routeChainMethods = map[string]bool{
+	"Methods":     true,
+	"Path":        true,
+	"HandleFunc":  true,
+	"Handle":      true,
+	"HandlerFunc": true,
+	"Handler":     true,
+	"Name":        true,
+	"Schemes":     true,
+	"Headers":     true,
+	"Queries":     true,
+	"Host":        true,
+}
+
+// routeParamRegexp matches a parameter of a route path: :id and *path of gin and echo, {path...} of http.ServeMux
+// and {id} or {id:[0-9]+} of chi and gorilla/mux, whose regular expression may hold braces.
+var routeParamRegexp = regexp.MustCompile(`:(\w+)|\*(\w+)|\{(\w+)\.\.\.\}|` + routePlaceholderPattern)
+
+// routePlaceholderPattern matches a parameter of an OpenAPI path, {id}, or of a chi and gorilla/mux path, {id:[0-9]{1,9}}.
+const routePlaceholderPattern = `\{(\w+)(?::((?:[^{}]|\{[^{}]*\})*))?\}`
+
+// routePlaceholderRegexp matches a parameter of a route path, with its regular expression.
+var routePlaceholderRegexp = regexp.MustCompile(routePlaceholderPattern)
+
+// majorVersionRegexp matches the last element of the path of a major version of a module, e.g. v4 of github.com/labstack/echo/v4.
+var majorVersionRegexp = regexp.MustCompile(`^v[0-9]+$`)
+
+// routerTypes the types of the routers of gin, echo, chi, gorilla/mux and http.ServeMux,
+// by the name of their package, which a function may take to register routes on.
+var routerTypes = map[string]bool{
+	"gin.Engine":      true,
+	"gin.RouterGroup": true,
+	"gin.IRouter":     true,
+	"gin.IRoutes":     true,
+	"echo.Echo":       true,
+	"echo.Group":      true,
+	"chi.Router":      true,
+	"chi.Mux":         true,
+	"mux.Router":      true,
+	"http.ServeMux":   true,
+}
+
+// anyReceiver the receiver of a method registered through a value, whose type is not known without type checking.
+const anyReceiver = "?"
+
+// routeHandler identifies a handler: a function or a variable of a package, or a method of a type.
+type routeHandler struct {
+	pkgPath string
+	recv    string
+	name    string
+}
+
+// routerNode a router or a group of routes, whose paths are prefixed by the prefixes of the node and its parents.
+type routerNode struct {
+	parent *routerNode
+	// others the other parents of the param of a function called with several routers,
+	// e.g. registerAccounts(v1) and registerAccounts(v2)
+	others []*routerNode
+	prefix string
+}
+
+// parents returns the parent of the node and its other parents.
+func (node *routerNode) parents() []*routerNode {
+	if node.parent == nil {
+		return node.others
+	}
+
+	return append([]*routerNode{node.parent}, node.others...)
+}
+
+// hasAncestor whether a node is the node itself or one of its parents.
+func (node *routerNode) hasAncestor(ancestor *routerNode) bool {
+	return node.hasAncestorWithin(ancestor, 64)
+}
+
+// hasAncestorWithin whether a node is the node itself or one of its parents up to a depth,
+// a router mounted into itself would loop.
+func (node *routerNode) hasAncestorWithin(ancestor *routerNode, depth int) bool {
+	if node == nil || depth == 0 {
+		return false
+	}
+	if node == ancestor {
+		return true
+	}
+
+	for _, parent := range node.parents() {
+		if parent.hasAncestorWithin(ancestor, depth-1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fullPrefixes returns the prefixes of the node and its parents, one for each router the param of a function is bound to.
+func (node *routerNode) fullPrefixes() []string {
+	return node.fullPrefixesWithin(64)
+}
+
+// fullPrefixesWithin returns the prefixes of the node and its parents up to a depth, a router mounted into itself would loop.
+func (node *routerNode) fullPrefixesWithin(depth int) []string {
+	parents := node.parents()
+	if len(parents) == 0 || depth == 1 {
+		return []string{node.prefix}
+	}
+
+	var prefixes []string
+	for _, parent := range parents {
+		for _, prefix := range parent.fullPrefixesWithin(depth - 1) {
+			prefixes = append(prefixes, joinRoutePath(prefix, node.prefix))
+		}
+	}
+
+	return prefixes
+}
+
+// joinRoutePath appends a path to a prefix, a relative path is separated by a slash as gin joins them.
+func joinRoutePath(prefix, path string) string {
+	if prefix != "" && path != "" && !strings.HasPrefix(path, "/") && !strings.HasSuffix(prefix, "/") {
+		return prefix + "/" + path
+	}
+
+	return prefix + path
+}
+
+// inferredRoute a route registered by the code, its path is completed by the prefix of its router
+// once the code mounting the router is read.
+type inferredRoute struct {
+	router *routerNode
+	method string
+	path   string
+}
+
+// routeInference infers the routes of the handlers from the code registering them.
+type routeInference struct {
+	parser *Parser
+	// routers the routers by their variables
+	routers map[interface{}]*routerNode
+	// handlers the routes registered for the handlers declared as functions
+	handlers map[routeHandler][]inferredRoute
+	// literals the routes registered for the handlers written as function literals, by their doc comments
+	literals map[*ast.CommentGroup][]inferredRoute
+	// literalFiles the files of the doc comments of the function literals
+	literalFiles map[*ast.CommentGroup]*ast.File
+	// chained the calls of a route chain which are read with the outermost call
+	chained map[*ast.CallExpr]bool
+	// funcs the declarations of the functions and methods, to bind the routers passed to them to their params
+	funcs map[routeHandler][]*ast.FuncDecl
+	// funcFiles the files of the declarations of the functions and methods
+	funcFiles map[*ast.FuncDecl]*AstFileInfo
+}
+
+// inferRoutes infers the route of each operation without a @Router from the code registering its handler,
+// such as r.GET("/accounts/:id", c.ShowAccount) of gin, a route of echo, chi, gorilla/mux
+// or mux.HandleFunc("GET /items/{id}", getItem) of http.ServeMux, the prefixes of the groups of routes included.
+func (parser *Parser) inferRoutes() error {
+	inference := &routeInference{
+		parser:   parser,
+		routers:  make(map[interface{}]*routerNode),
+		handlers: make(map[routeHandler][]inferredRoute),
+		literals: make(map[*ast.CommentGroup][]inferredRoute),
+		chained:  make(map[*ast.CallExpr]bool),
+		funcs:    make(map[routeHandler][]*ast.FuncDecl),
+
+		literalFiles: make(map[*ast.CommentGroup]*ast.File),
+		funcFiles:    make(map[*ast.FuncDecl]*AstFileInfo),
+	}
+
+	err := parser.packages.RangeFiles(func(fileInfo *AstFileInfo) error {
+		if fileInfo.ParseFlag&ParseOperations == ParseNone {
+			return nil
+		}
+
+		for _, decl := range fileInfo.File.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+
+			handler, _ := declaredHandler(fileInfo.PackagePath, funcDecl)
+			inference.funcs[handler] = append(inference.funcs[handler], funcDecl)
+			if handler.recv != "" {
+				// a method called through a value is matched by its name
+				anyMethod := routeHandler{recv: anyReceiver, name: handler.name}
+				inference.funcs[anyMethod] = append(inference.funcs[anyMethod], funcDecl)
+			}
+			inference.funcFiles[funcDecl] = fileInfo
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = parser.packages.RangeFiles(func(fileInfo *AstFileInfo) error {
+		if fileInfo.ParseFlag&ParseOperations == ParseNone {
+			return nil
+		}
+
+		ast.Inspect(fileInfo.File, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.AssignStmt:
+				if len(n.Lhs) == len(n.Rhs) {
+					for i := range n.Lhs {
+						inference.assignRouter(fileInfo, n.Lhs[i], n.Rhs[i])
+					}
+				}
+			case *ast.ValueSpec:
+				if len(n.Names) == len(n.Values) {
+					for i := range n.Names {
+						inference.assignRouter(fileInfo, n.Names[i], n.Values[i])
+					}
+				}
+			case *ast.CallExpr:
+				inference.readCall(fileInfo, n)
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	parser.inferredRoutes = make(map[*ast.Comment][]RouteProperties)
+	parser.inferredRouteDocs = make(map[*ast.File][]*ast.CommentGroup)
+
+	for doc, routes := range inference.literals {
+		parser.inferredRoutes[doc.List[0]] = inference.routeProperties(routes)
+		file := inference.literalFiles[doc]
+		parser.inferredRouteDocs[file] = append(parser.inferredRouteDocs[file], doc)
+	}
+	for _, docs := range parser.inferredRouteDocs {
+		sort.Slice(docs, func(i, j int) bool {
+			return docs[i].Pos() < docs[j].Pos()
+		})
+	}
+
+	annotatedMethods := make(map[string]int)
+	_ = parser.packages.RangeFiles(func(fileInfo *AstFileInfo) error {
+		for _, decl := range fileInfo.File.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv != nil && hasAnnotations(funcDecl.Doc) {
+				annotatedMethods[funcDecl.Name.Name]++
+			}
+		}
+
+		return nil
+	})
+
+	// the methods registered through a value whose routes are not inferred, as several annotated methods have their name
+	ambiguousMethods := make(map[string]bool)
+
+	return parser.packages.RangeFiles(func(fileInfo *AstFileInfo) error {
+		if fileInfo.ParseFlag&ParseOperations == ParseNone {
+			return nil
+		}
+
+		for _, decl := range fileInfo.File.Decls {
+			doc, ok := getFuncDoc(decl)
+			if !ok || !hasAnnotations(doc) {
+				continue
+			}
+
+			handler, ok := declaredHandler(fileInfo.PackagePath, decl)
+			if !ok {
+				continue
+			}
+
+			routes := inference.handlers[handler]
+			if handler.recv != "" {
+				anyRoutes := inference.handlers[routeHandler{recv: anyReceiver, name: handler.name}]
+				switch {
+				case annotatedMethods[handler.name] == 1:
+					routes = append(routes, anyRoutes...)
+				case len(anyRoutes) > 0 && !ambiguousMethods[handler.name]:
+					ambiguousMethods[handler.name] = true
+					err := fmt.Errorf("cannot infer the routes of the handlers registered through a value by the method %s, "+
+						"%d annotated methods have its name", handler.name, annotatedMethods[handler.name])
+					if parser.Strict {
+						return err
+					}
+					parser.debug.Printf("warning: %s, add a @Router to them", err)
+				}
+			}
+			if len(routes) == 0 {
+				continue
+			}
+
+			parser.inferredRoutes[doc.List[0]] = inference.routeProperties(routes)
+		}
+
+		return nil
+	})
+}
+
+// assignRouter makes the router of a variable a group of the router it is assigned, e.g. v1 := r.Group("/api/v1").
+func (inference *routeInference) assignRouter(fileInfo *AstFileInfo, lhs, rhs ast.Expr) {
+	switch rhs.(type) {
+	case *ast.CallExpr, *ast.Ident, *ast.SelectorExpr:
+	default:
+		return
+	}
+
+	key := inference.routerKey(fileInfo, lhs)
+	if key == nil {
+		return
+	}
+
+	inference.bindRouter(key, inference.routerOf(fileInfo, rhs))
+}
+
+// bindRouter makes the router of a key a group of a parent router. The node of a router already used keeps the routes
+// registered on it, so the order the code is read in does not matter, unless the router is reassigned from itself,
+// e.g. r = r.Group("/api"), which makes it a new group.
+func (inference *routeInference) bindRouter(key interface{}, parent *routerNode) {
+	node, ok := inference.routers[key]
+	if !ok || parent.hasAncestor(node) {
+		inference.routers[key] = &routerNode{parent: parent}
+
+		return
+	}
+
+	node.parent = parent
+}
+
+// bindParams binds the routers a call passes to a function of a parsed package to the params of the function
+// whose types are routers, e.g. registerAccounts(v1.Group("/accounts")) for func registerAccounts(g *gin.RouterGroup).
+// A param is bound to the router of each call passing one, the routes registered on it get the prefixes of all of them.
+func (inference *routeInference) bindParams(fileInfo *AstFileInfo, call *ast.CallExpr) {
+	handler, ok := inference.handlerOf(fileInfo, call.Fun)
+	if !ok || len(inference.funcs[handler]) != 1 {
+		return
+	}
+
+	funcDecl := inference.funcs[handler][0]
+	funcFile := inference.funcFiles[funcDecl]
+
+	var params []*ast.Ident
+	for _, field := range funcDecl.Type.Params.List {
+		isRouter := isRouterType(funcFile.File, field.Type)
+		for _, name := range field.Names {
+			if !isRouter {
+				name = nil
+			}
+			params = append(params, name)
+		}
+	}
+
+	for i, arg := range call.Args {
+		if i >= len(params) {
+			break
+		}
+
+		param := params[i]
+		if param == nil || param.Obj == nil {
+			continue
+		}
+
+		parent := inference.routerOf(fileInfo, arg)
+		node, ok := inference.routers[param.Obj]
+		if ok && parent.hasAncestor(node) {
+			// a recursive call passing the router on
+			continue
+		}
+		if ok && node.parent != nil {
+			if !slices.Contains(node.parents(), parent) {
+				node.others = append(node.others, parent)
+			}
+
+			continue
+		}
+
+		inference.bindRouter(param.Obj, parent)
+	}
+}
+
+// isRouterType whether a type is a router of gin, echo, chi, gorilla/mux or http.ServeMux.
+func isRouterType(file *ast.File, typ ast.Expr) bool {
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+
+	sel, ok := typ.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	path := importPath(file, pkg.Name)
+
+	return path != "" && routerTypes[importPathName(path)+"."+sel.Sel.Name]
+}
+
+// routerKey returns the key of the router a variable refers to.
+func (inference *routeInference) routerKey(fileInfo *AstFileInfo, expr ast.Expr) interface{} {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		if x.Name == "_" {
+			return nil
+		}
+		if x.Obj != nil {
+			return x.Obj
+		}
+
+		return fileInfo.PackagePath + "." + x.Name
+	case *ast.SelectorExpr:
+		return fileInfo.PackagePath + "." + types.ExprString(x)
+	}
+
+	return nil
+}
+
+// routerOf returns the router an expression is, a call of Group("/prefix") of gin and echo or PathPrefix("/prefix") of gorilla/mux
+// is a group of routes of the router it is called on.
+func (inference *routeInference) routerOf(fileInfo *AstFileInfo, expr ast.Expr) *routerNode {
+	switch x := expr.(type) {
+	case *ast.ParenExpr:
+		return inference.routerOf(fileInfo, x.X)
+	case *ast.StarExpr:
+		return inference.routerOf(fileInfo, x.X)
+	case *ast.UnaryExpr:
+		return inference.routerOf(fileInfo, x.X)
+	case *ast.Ident, *ast.SelectorExpr:
+		key := inference.routerKey(fileInfo, x)
+		node, ok := inference.routers[key]
+		if !ok {
+			node = &routerNode{}
+			inference.routers[key] = node
+		}
+
+		return node
+	case *ast.CallExpr:
+		sel, ok := x.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return &routerNode{}
+		}
+		if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Obj == nil && importPath(fileInfo.File, pkg.Name) != "" {
+			// a new router, e.g. gin.New() or chi.NewRouter()
+			return &routerNode{}
+		}
+
+		switch sel.Sel.Name {
+		case "Group", "PathPrefix":
+			if len(x.Args) > 0 {
+				if prefix, ok := inference.stringValue(fileInfo, x.Args[0]); ok {
+					return &routerNode{parent: inference.routerOf(fileInfo, sel.X), prefix: prefix}
+				}
+			}
+		}
+
+		// a router with middlewares, e.g. r.With(auth) of chi, or the router of a group, e.g. Subrouter() of gorilla/mux
+		return inference.routerOf(fileInfo, sel.X)
+	}
+
+	return &routerNode{}
+}
+
+// readCall reads a call registering a route, mounting a router or grouping routes in a function, as chi does.
+func (inference *routeInference) readCall(fileInfo *AstFileInfo, call *ast.CallExpr) {
+	if inference.chained[call] {
+		return
+	}
+
+	inference.bindParams(fileInfo, call)
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+
+	switch sel.Sel.Name {
+	case "Mount":
+		// r.Mount("/admin", adminRouter) of chi
+		if len(call.Args) == 2 {
+			prefix, ok := inference.stringValue(fileInfo, call.Args[0])
+			key := inference.routerKey(fileInfo, call.Args[1])
+			if ok && key != nil {
+				node := inference.routerOf(fileInfo, call.Args[1])
+				node.parent, node.prefix = inference.routerOf(fileInfo, sel.X), prefix
+			}
+		}
+
+		return
+	case "Route", "Group":
+		// r.Route("/admin", func(r chi.Router) {...}) or r.Group(func(r chi.Router) {...}) of chi
+		if len(call.Args) == 0 {
+			return
+		}
+
+		lit, ok := call.Args[len(call.Args)-1].(*ast.FuncLit)
+		if !ok || len(lit.Type.Params.List) == 0 || len(lit.Type.Params.List[0].Names) == 0 {
+			return
+		}
+
+		node := &routerNode{parent: inference.routerOf(fileInfo, sel.X)}
+		if len(call.Args) == 2 {
+			node.prefix, _ = inference.stringValue(fileInfo, call.Args[0])
+		}
+
+		key := inference.routerKey(fileInfo, lit.Type.Params.List[0].Names[0])
+		if key != nil {
+			inference.bindRouter(key, node)
+		}
+
+		return
+	}
+
+	inference.readRoute(fileInfo, call)
+}
+
+// readRoute reads the route a chain of calls registers, such as r.GET("/accounts/:id", c.ShowAccount),
+// r.Handle("GET", "/accounts/:id", c.ShowAccount), mux.HandleFunc("GET /items/{id}", getItem)
+// or r.Path("/items/{id}").Methods("GET").HandlerFunc(getItem).
+func (inference *routeInference) readRoute(fileInfo *AstFileInfo, call *ast.CallExpr) {
+	var chain []*ast.CallExpr
+	for current := call; ; {
+		sel := current.Fun.(*ast.SelectorExpr)
+		chain = append(chain, current)
+
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok {
+			break
+		}
+		innerSel, ok := inner.Fun.(*ast.SelectorExpr)
+		if !ok || !routeChainMethods[innerSel.Sel.Name] {
+			break
+		}
+
+		inference.chained[inner] = true
+		current = inner
+	}
+
+	var (
+		methods  []string
+		path     string
+		hasPath  bool
+		handlers []ast.Expr
+		// handlerCall the call the handlers are passed to
+		handlerCall *ast.CallExpr
+	)
+
+	for _, link := range chain {
+		name, args := link.Fun.(*ast.SelectorExpr).Sel.Name, link.Args
+
+		switch {
+		case routeRegistrars[name] != "" && len(args) >= 2:
+			if value, ok := inference.stringValue(fileInfo, args[0]); ok {
+				methods = append(methods, routeRegistrars[name])
+				path, hasPath, handlers, handlerCall = value, true, args[1:], link
+			}
+		case (name == "Handle" || name == "Method" || name == "MethodFunc") && len(args) == 3:
+			method, methodOK := inference.stringValue(fileInfo, args[0])
+			value, pathOK := inference.stringValue(fileInfo, args[1])
+			if methodOK && pathOK {
+				methods = append(methods, strings.ToUpper(method))
+				path, hasPath, handlers, handlerCall = value, true, args[2:], link
+			}
+		case (name == "HandleFunc" || name == "Handle") && len(args) == 2:
+			if pattern, ok := inference.stringValue(fileInfo, args[0]); ok {
+				method, value := splitServeMuxPattern(pattern)
+				if method != "" {
+					methods = append(methods, method)
+				}
+				path, hasPath, handlers, handlerCall = value, true, args[1:], link
+			}
+		case name == "Path" && len(args) == 1:
+			path, hasPath = inference.stringValue(fileInfo, args[0])
+		case name == "Methods":
+			for _, arg := range args {
+				if method, ok := inference.stringValue(fileInfo, arg); ok {
+					methods = append(methods, strings.ToUpper(method))
+				}
+			}
+		case (name == "HandlerFunc" || name == "Handler") && len(args) == 1:
+			handlers, handlerCall = args, link
+		}
+	}
+
+	if !hasPath || len(handlers) == 0 {
+		return
+	}
+	if len(methods) == 0 {
+		inference.parser.debug.Printf("Skipping route %s registered for any method", path)
+
+		return
+	}
+
+	router := inference.routerOf(fileInfo, chain[len(chain)-1].Fun.(*ast.SelectorExpr).X)
+
+	// the handler follows the middlewares in gin and precedes them in echo, each of them is a candidate
+	for i, handlerExpr := range handlers {
+		var routes []inferredRoute
+		for _, method := range methods {
+			if _, ok := allMethod[method]; ok {
+				routes = append(routes, inferredRoute{router: router, method: method, path: path})
+			}
+		}
+
+		if lit, ok := unwrapHandler(handlerExpr).(*ast.FuncLit); ok {
+			// the doc of a function literal follows the preceding argument
+			from := handlerCall.Lparen
+			if index := len(handlerCall.Args) - len(handlers) + i; index > 0 {
+				from = handlerCall.Args[index-1].End()
+			}
+
+			if doc := literalDoc(fileInfo.File, from, lit); doc != nil {
+				inference.literals[doc] = append(inference.literals[doc], routes...)
+				inference.literalFiles[doc] = fileInfo.File
+			}
+
+			continue
+		}
+
+		handler, ok := inference.handlerOf(fileInfo, handlerExpr)
+		if ok {
+			inference.handlers[handler] = append(inference.handlers[handler], routes...)
+		}
+	}
+}
+
+// handlerOf returns the handler a registration refers to, a method of a value is matched by its name only.
+func (inference *routeInference) handlerOf(fileInfo *AstFileInfo, expr ast.Expr) (routeHandler, bool) {
+	switch x := unwrapHandler(expr).(type) {
+	case *ast.Ident:
+		if x.Obj != nil && x.Obj.Kind != ast.Fun && x.Obj.Kind != ast.Var {
+			return routeHandler{}, false
+		}
+
+		return routeHandler{pkgPath: fileInfo.PackagePath, name: x.Name}, true
+	case *ast.SelectorExpr:
+		if pkg, ok := x.X.(*ast.Ident); ok && pkg.Obj == nil {
+			matchedPkgPaths, _ := inference.parser.packages.findPackagePathFromImports(pkg.Name, fileInfo.File)
+			if len(matchedPkgPaths) > 0 {
+				return routeHandler{pkgPath: matchedPkgPaths[0], name: x.Sel.Name}, true
+			}
+			if importPath(fileInfo.File, pkg.Name) != "" {
+				// a handler of a package which is not parsed
+				return routeHandler{}, false
+			}
+		}
+
+		return routeHandler{recv: anyReceiver, name: x.Sel.Name}, true
+	}
+
+	return routeHandler{}, false
+}
+
+// routeProperties completes the paths of the routes with the prefixes of their routers.
+func (inference *routeInference) routeProperties(routes []inferredRoute) []RouteProperties {
+	var result []RouteProperties
+
+	seen := make(map[RouteProperties]bool)
+	for _, route := range routes {
+		for _, prefix := range route.router.fullPrefixes() {
+			properties := RouteProperties{
+				HTTPMethod: route.method,
+				Path:       inference.parser.routePath(joinRoutePath(prefix, route.path)),
+			}
+			if seen[properties] {
+				continue
+			}
+			seen[properties] = true
+
+			inference.parser.debug.Printf("Inferred route %s %s", properties.HTTPMethod, properties.Path)
+			result = append(result, properties)
+		}
+	}
+
+	return result
+}
+
+// stringValue evaluates a string literal or a constant, such as http.MethodGet.
+func (inference *routeInference) stringValue(fileInfo *AstFileInfo, expr ast.Expr) (string, bool) {
+	if sel, ok := expr.(*ast.SelectorExpr); ok && importPath(fileInfo.File, types.ExprString(sel.X)) == "net/http" {
+		if method := strings.ToUpper(strings.TrimPrefix(sel.Sel.Name, "Method")); method != strings.ToUpper(sel.Sel.Name) {
+			return method, true
+		}
+	}
+
+	value, err := inference.parser.evaluateLiteral(fileInfo.File, nil, expr)
+	if err != nil {
+		return "", false
+	}
+
+	text, ok := value.(string)
+
+	return text, ok
+}
+
+// routePath converts the path of a route to an OpenAPI path relative to the base path,
+// e.g. /api/v1/accounts/:id is /accounts/{id} with @BasePath /api/v1.
+func (parser *Parser) routePath(path string) string {
+	path = routeParamRegexp.ReplaceAllStringFunc(path, func(param string) string {
+		matches := routeParamRegexp.FindStringSubmatch(param)
+		for _, name := range matches[1:4] {
+			if name != "" {
+				return "{" + name + "}"
+			}
+		}
+
+		// the regular expression of a parameter is kept for its type
+		return param
+	})
+	path = strings.ReplaceAll(path, "{$}", "")
+
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	basePath := strings.TrimSuffix(parser.swagger.BasePath, "/")
+	if basePath != "" && (path == basePath || strings.HasPrefix(path, basePath+"/")) {
+		path = strings.TrimPrefix(path, basePath)
+		if path == "" {
+			path = "/"
+		}
+	}
+
+	return path
+}
+
+// splitServeMuxPattern splits a pattern of http.ServeMux into its method and its path, without its host,
+// e.g. GET example.com/items/{id} into GET and /items/{id}.
+func splitServeMuxPattern(pattern string) (method, path string) {
+	path = pattern
+	if fields := strings.Fields(pattern); len(fields) == 2 {
+		method, path = strings.ToUpper(fields[0]), fields[1]
+	}
+
+	if pos := strings.IndexByte(path, '/'); pos > 0 {
+		path = path[pos:]
+	}
+
+	return method, path
+}
+
+// unwrapHandler returns the function a handler converts, e.g. getItem of http.HandlerFunc(getItem).
+func unwrapHandler(expr ast.Expr) ast.Expr {
+	for {
+		switch x := expr.(type) {
+		case *ast.ParenExpr:
+			expr = x.X
+
+			continue
+		case *ast.CallExpr:
+			var name string
+			switch fun := x.Fun.(type) {
+			case *ast.Ident:
+				name = fun.Name
+			case *ast.SelectorExpr:
+				name = fun.Sel.Name
+			}
+
+			if name == "HandlerFunc" && len(x.Args) == 1 {
+				expr = x.Args[0]
+
+				continue
+			}
+		}
+
+		return expr
+	}
+}
+
+// declaredHandler returns the handler a declaration with a doc comment declares.
+func declaredHandler(pkgPath string, decl ast.Decl) (routeHandler, bool) {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		handler := routeHandler{pkgPath: pkgPath, name: d.Name.Name}
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			recv := d.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			switch r := recv.(type) {
+			case *ast.IndexExpr:
+				recv = r.X
+			case *ast.IndexListExpr:
+				recv = r.X
+			}
+			handler.recv = types.ExprString(recv)
+		}
+
+		return handler, true
+	case *ast.GenDecl:
+		if len(d.Specs) == 0 {
+			return routeHandler{}, false
+		}
+		if valueSpec, ok := d.Specs[0].(*ast.ValueSpec); ok && len(valueSpec.Names) > 0 {
+			return routeHandler{pkgPath: pkgPath, name: valueSpec.Names[0].Name}, true
+		}
+	}
+
+	return routeHandler{}, false
+}
+
+// literalDoc returns the annotated comment written between a position and a function literal,
+// as the doc of a handler registered with the function literal.
+func literalDoc(file *ast.File, from token.Pos, lit *ast.FuncLit) *ast.CommentGroup {
+	var doc *ast.CommentGroup
+	for _, commentGroup := range file.Comments {
+		if commentGroup.Pos() > from && commentGroup.End() <= lit.Pos() {
+			doc = commentGroup
+		}
+	}
+
+	if !hasAnnotations(doc) {
+		return nil
+	}
+
+	return doc
+}
+
+// hasAnnotations whether a comment has an annotation, a line starting with @.
+func hasAnnotations(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "@") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// importPath returns the path of the package a file imports by a name.
+func importPath(file *ast.File, name string) string {
+	for _, imp := range file.Imports {
+		path := strings.Trim(imp.Path.Value, `"`)
+		if imp.Name != nil {
+			if imp.Name.Name == name {
+				return path
+			}
+
+			continue
+		}
+
+		if importPathName(path) == name {
+			return path
+		}
+	}
+
+	return ""
+}
+
+// importPathName returns the name a package is imported by without an import name, the last element of its path.
+func importPathName(path string) string {
+	parts := strings.Split(path, "/")
+	last := parts[len(parts)-1]
+	if len(parts) > 1 && majorVersionRegexp.MatchString(last) {
+		// a major version of a module, e.g. github.com/labstack/echo/v4
+		last = parts[len(parts)-2]
+	}
+
+	return last
+}
+
+// routeParam a parameter of a route path, with the regular expression of chi or gorilla/mux constraining it.
+type routeParam struct {
+	name    string
+	pattern string
+}
+
+// enumPatternRegexp matches a regular expression of alternative words, e.g. cat|dog or (cat|dog).
+var enumPatternRegexp = regexp.MustCompile(`^\(?([\w-]+(?:\|[\w-]+)+)\)?$`)
+
+// routePathParams returns the path of a route without the regular expressions of its parameters, and its parameters,
+// e.g. /items/{id} and id constrained by [0-9]+ for /items/{id:[0-9]+}.
+func routePathParams(path string) (string, []routeParam) {
+	var params []routeParam
+	path = routePlaceholderRegexp.ReplaceAllStringFunc(path, func(placeholder string) string {
+		matches := routePlaceholderRegexp.FindStringSubmatch(placeholder)
+		params = append(params, routeParam{name: matches[1], pattern: matches[2]})
+
+		return "{" + matches[1] + "}"
+	})
+
+	return path, params
+}
+
+// hasRouteParam whether a route has a parameter.
+func hasRouteParam(params []routeParam, name string) bool {
+	for _, param := range params {
+		if param.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkPathParams reports the path params of an operation which none of its routes has, which is an error in Strict mode.
+func checkPathParams(parser *Parser, operation *Operation) error {
+	if len(operation.RouterProperties) == 0 {
+		return nil
+	}
+
+	var paths []string
+	var params []routeParam
+	for _, routeProperties := range operation.RouterProperties {
+		path, routeParams := routePathParams(routeProperties.Path)
+		paths = append(paths, routeProperties.HTTPMethod+" "+path)
+		params = append(params, routeParams...)
+	}
+
+	for _, param := range operation.Parameters {
+		if param.In != "path" || hasRouteParam(params, param.Name) {
+			continue
+		}
+
+		err := fmt.Errorf("path param %s is not in route %s", param.Name, strings.Join(paths, ", "))
+		if parser.Strict {
+			return err
+		}
+
+		parser.debug.Printf("warning: %s", err)
+	}
+
+	return nil
+}
+
+// addRouteParams adds the parameters of a route which the operation does not declare as required path params.
+func addRouteParams(parser *Parser, params []spec.Parameter, routeParams []routeParam) []spec.Parameter {
+	for _, routeParam := range routeParams {
+		declared := false
+		for _, param := range params {
+			if param.In == "path" && param.Name == routeParam.name {
+				declared = true
+
+				break
+			}
+		}
+		if declared {
+			continue
+		}
+
+		parser.debug.Printf("Adding undeclared path param %s", routeParam.name)
+		params = append(params, routeParam.parameter())
+	}
+
+	return params
+}
+
+// parameter creates a required path param: an integer if its regular expression matches digits only,
+// an enum if it matches alternative words and otherwise a string, with the regular expression as its pattern.
+func (param routeParam) parameter() spec.Parameter {
+	switch {
+	case param.pattern == "":
+		return createParameter("path", "", param.name, PRIMITIVE, STRING, "", true, nil, "")
+	case isIntegerPattern(param.pattern):
+		return createParameter("path", "", param.name, PRIMITIVE, INTEGER, "", true, nil, "")
+	}
+
+	if matches := enumPatternRegexp.FindStringSubmatch(param.pattern); matches != nil {
+		var enums []interface{}
+		for _, value := range strings.Split(matches[1], "|") {
+			enums = append(enums, value)
+		}
+
+		return createParameter("path", "", param.name, PRIMITIVE, STRING, "", true, enums, "")
+	}
+
+	result := createParameter("path", "", param.name, PRIMITIVE, STRING, "", true, nil, "")
+	result.Pattern = param.pattern
+	if !strings.HasPrefix(result.Pattern, "^") {
+		// the regular expression matches the whole parameter
+		result.Pattern = "^" + result.Pattern
+	}
+	if !strings.HasSuffix(result.Pattern, "$") {
+		result.Pattern += "$"
+	}
+
+	return result
+}
+
+// isIntegerPattern whether a regular expression matches digits only, e.g. [0-9]+ or \d{1,9},
+// after an optional leading minus sign, e.g. ^-?[0-9]+$.
+func isIntegerPattern(pattern string) bool {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return false
+	}
+
+	re = re.Simplify()
+	if re.Op != syntax.OpConcat {
+		return matchesDigitsOnly(re)
+	}
+
+	leading := true
+	for _, sub := range re.Sub {
+		switch {
+		case isAnchor(sub):
+			continue
+		case leading && isMinusSign(sub):
+		case !matchesDigitsOnly(sub):
+			return false
+		}
+		leading = false
+	}
+
+	return true
+}
+
+// isAnchor whether a regular expression matches an empty string at a position, such as ^ or $.
+func isAnchor(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText, syntax.OpEmptyMatch:
+		return true
+	}
+
+	return false
+}
+
+// isMinusSign whether a regular expression matches a minus sign or an optional one, - or -?.
+func isMinusSign(re *syntax.Regexp) bool {
+	if re.Op == syntax.OpQuest {
+		re = re.Sub[0]
+	}
+
+	return re.Op == syntax.OpLiteral && len(re.Rune) == 1 && re.Rune[0] == '-'
+}
+
+// matchesDigitsOnly whether a regular expression matches digits only.
+func matchesDigitsOnly(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if r < '0' || r > '9' {
+				return false
+			}
+		}
+
+		return true
+	case syntax.OpCharClass:
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			if re.Rune[i] < '0' || re.Rune[i+1] > '9' {
+				return false
+			}
+		}
+
+		return true
+	case syntax.OpCapture, syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat, syntax.OpConcat, syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			if !isAnchor(sub) && !matchesDigitsOnly(sub) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,413 @@
+package swag
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferRoutes(t *testing.T) {
+	t.Parallel()
+
+	controllerSrc := `
+package controller
+
+type Controller struct{}
+
+// ShowAccount godoc
+// @Summary Show an account
+// @Success 200 {string} string
+func (c *Controller) ShowAccount() {
+}
+
+// ListAccounts godoc
+// @Summary List accounts
+// @Success 200 {string} string
+func ListAccounts() {
+}
+
+// Legacy godoc
+// @Summary Legacy
+// @Router /legacy [get]
+func Legacy() {
+}
+`
+	mainSrc := `
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/mux"
+
+	"controller"
+)
+
+const v1Prefix = "/api/v1"
+
+// @BasePath /api/v1
+
+func ginRoutes() {
+	c := &controller.Controller{}
+	r := gin.New()
+	v1 := r.Group(v1Prefix)
+	{
+		accounts := v1.Group("/accounts")
+		accounts.GET(":id", auth, c.ShowAccount)
+		accounts.GET("", controller.ListAccounts)
+		accounts.GET("/legacy", controller.Legacy)
+	}
+}
+
+func chiRoutes() {
+	r := chi.NewRouter()
+	admin := chi.NewRouter()
+	admin.Get("/stats/{period:[a-z]+}", stats)
+	r.Route("/api/v1/reports", func(r chi.Router) {
+		r.With(auth).Post("/",
+			// @Summary Create a report
+			// @Success 201 {string} string
+			func(w http.ResponseWriter, r *http.Request) {
+			})
+	})
+	r.Mount("/api/v1/admin", admin)
+}
+
+func gorillaRoutes() {
+	r := mux.NewRouter()
+	s := r.PathPrefix("/api/v1/items").Subrouter()
+	s.HandleFunc("/{id}", getItem).Methods(http.MethodGet, "PUT")
+}
+
+func serveMuxRoutes() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE example.com/api/v1/items/{id}", http.HandlerFunc(deleteItem))
+	mux.HandleFunc("/api/v1/files/{path...}", getItem)
+}
+
+// @Summary Get stats
+// @Success 200 {string} string
+func stats(w http.ResponseWriter, r *http.Request) {
+}
+
+// @Summary Get an item
+// @Success 200 {string} string
+func getItem(w http.ResponseWriter, r *http.Request) {
+}
+
+// @Summary Delete an item
+// @Success 204 {string} string
+func deleteItem(w http.ResponseWriter, r *http.Request) {
+}
+
+func auth() {
+}
+`
+	p := New(SetInferRoutes(true))
+	p.swagger.BasePath = "/api/v1"
+	assert.NoError(t, p.packages.ParseFile("controller", "controller/controller.go", controllerSrc, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("main", "main.go", mainSrc, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.inferRoutes())
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	var routes []string
+	for path, item := range p.swagger.Paths.Paths {
+		for method := range allMethod {
+			if *refRouteMethodOp(&item, method) != nil {
+				routes = append(routes, method+" "+path)
+			}
+		}
+	}
+	sort.Strings(routes)
+
+	assert.Equal(t, []string{
+		"DELETE /items/{id}",
+		"GET /accounts",
+		"GET /accounts/{id}",
+		"GET /admin/stats/{period}",
+		"GET /items/{id}",
+		"GET /legacy",
+		"POST /reports/",
+		"PUT /items/{id}",
+	}, routes)
+
+	assert.Equal(t, "Show an account", p.swagger.Paths.Paths["/accounts/{id}"].Get.Summary)
+	assert.Equal(t, "Create a report", p.swagger.Paths.Paths["/reports/"].Post.Summary)
+	assert.Equal(t, "Delete an item", p.swagger.Paths.Paths["/items/{id}"].Delete.Summary)
+}
+
+func TestInferRoutesOfPassedRouters(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/mux"
+)
+
+func registerAccounts(g *gin.RouterGroup) {
+	g.GET("/:id", showAccount)
+	registerSettings(g.Group("/settings"))
+}
+
+func main() {
+	r := gin.New()
+	v1 := r.Group("/api/v1")
+	registerAccounts(v1.Group("/accounts"))
+	registerAccounts(r.Group("/legacy"))
+
+	s := &server{}
+	s.routes(mux.NewRouter().PathPrefix("/api/v1/items").Subrouter(), "items")
+}
+
+func registerSettings(settings gin.IRouter) {
+	settings.GET("", showSettings)
+}
+
+type server struct{}
+
+func (s *server) routes(r *mux.Router, name string) {
+	r.HandleFunc("/{id}", getItem).Methods(http.MethodGet)
+}
+
+// @Summary Show an account
+// @Success 200 {string} string
+func showAccount(c *gin.Context) {
+}
+
+// @Summary Show the settings of an account
+// @Success 200 {string} string
+func showSettings(c *gin.Context) {
+}
+
+// @Summary Get an item
+// @Success 200 {string} string
+func getItem(w http.ResponseWriter, r *http.Request) {
+}
+`
+	p := New(SetInferRoutes(true))
+	p.swagger.BasePath = "/api/v1"
+	assert.NoError(t, p.packages.ParseFile("main", "main.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.inferRoutes())
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	var routes []string
+	for path, item := range p.swagger.Paths.Paths {
+		for method := range allMethod {
+			if *refRouteMethodOp(&item, method) != nil {
+				routes = append(routes, method+" "+path)
+			}
+		}
+	}
+	sort.Strings(routes)
+
+	// the routes of registerAccounts are registered on the routers of both its calls
+	assert.Equal(t, []string{
+		"GET /accounts/settings",
+		"GET /accounts/{id}",
+		"GET /items/{id}",
+		"GET /legacy/settings",
+		"GET /legacy/{id}",
+	}, routes)
+}
+
+func TestInferRoutesOfAmbiguousMethods(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type accounts struct{}
+
+type orders struct{}
+
+func main() {
+	r := gin.New()
+	a, o := newAccounts(), newOrders()
+	r.GET("/accounts", a.List)
+	r.GET("/orders", o.List)
+}
+
+// @Summary List the accounts
+// @Success 200 {string} string
+func (*accounts) List(c *gin.Context) {
+}
+
+// @Summary List the orders
+// @Success 200 {string} string
+func (*orders) List(c *gin.Context) {
+}
+`
+	logger := &testLogger{}
+	p := New(SetInferRoutes(true), SetDebugger(logger))
+	assert.NoError(t, p.packages.ParseFile("main", "main.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	// the receivers of a.List and o.List are not known, neither method gets a route
+	assert.NoError(t, p.inferRoutes())
+	assert.Empty(t, p.inferredRoutes)
+	assert.Contains(t, strings.Join(logger.Messages, "\n"),
+		"warning: cannot infer the routes of the handlers registered through a value by the method List, "+
+			"2 annotated methods have its name")
+
+	p = New(SetInferRoutes(true), SetStrict(true))
+	assert.NoError(t, p.packages.ParseFile("main", "main.go", src, ParseAll))
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	assert.ErrorContains(t, p.inferRoutes(), "cannot infer the routes of the handlers registered through a value by the method List")
+}
+
+func TestRoutePath(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	assert.Equal(t, "/accounts/{id}/files/{path}", p.routePath("/accounts/:id/files/*path"))
+	assert.Equal(t, "/items/{id:[0-9]+}", p.routePath("/items/{id:[0-9]+}"))
+	assert.Equal(t, "/files/{path}", p.routePath("/files/{path...}"))
+	assert.Equal(t, "/", p.routePath("/{$}"))
+	assert.Equal(t, "/a/b", p.routePath("/a//b"))
+
+	p.swagger.BasePath = "/api/v1/"
+	assert.Equal(t, "/items", p.routePath("/api/v1/items"))
+	assert.Equal(t, "/", p.routePath("/api/v1"))
+	assert.Equal(t, "/api/v10", p.routePath("/api/v10"))
+}
+
+func TestSplitServeMuxPattern(t *testing.T) {
+	t.Parallel()
+
+	method, path := splitServeMuxPattern("GET /items/{id}")
+	assert.Equal(t, "GET", method)
+	assert.Equal(t, "/items/{id}", path)
+
+	method, path = splitServeMuxPattern("post example.com/items")
+	assert.Equal(t, "POST", method)
+	assert.Equal(t, "/items", path)
+
+	method, path = splitServeMuxPattern("/items")
+	assert.Equal(t, "", method)
+	assert.Equal(t, "/items", path)
+}
+
+func TestParseRouteParams(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @Summary Show an item
+// @Param kind path string true "kind of the item"
+// @Success 200 {string} string
+// @Router /items/{kind}/{id:[0-9]+}/{slug}/{color:(red|green)}/{code:[a-z]{3}} [get]
+func ShowItem() {
+}
+// @Summary List the reports of a day
+// @Success 200 {string} string
+// @Router /reports/{date:\d{4}-\d{2}-\d{2}}/{ref:[0-9-]+} [get]
+func ListReports() {
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	item, ok := p.swagger.Paths.Paths["/items/{kind}/{id}/{slug}/{color}/{code}"]
+	assert.True(t, ok)
+
+	params := item.Get.Parameters
+	assert.Len(t, params, 5)
+	assert.Equal(t, "kind of the item", params[0].Description)
+
+	for _, param := range params {
+		assert.Equal(t, "path", param.In)
+		assert.True(t, param.Required)
+	}
+
+	assert.Equal(t, "id", params[1].Name)
+	assert.Equal(t, INTEGER, params[1].Type)
+	assert.Equal(t, "slug", params[2].Name)
+	assert.Equal(t, STRING, params[2].Type)
+	assert.Empty(t, params[2].Pattern)
+	assert.Equal(t, "color", params[3].Name)
+	assert.Equal(t, []interface{}{"red", "green"}, params[3].Enum)
+	assert.Equal(t, "code", params[4].Name)
+	assert.Equal(t, "^[a-z]{3}$", params[4].Pattern)
+
+	// digits with dashes are strings
+	params = p.swagger.Paths.Paths["/reports/{date}/{ref}"].Get.Parameters
+	if assert.Len(t, params, 2) {
+		assert.Equal(t, STRING, params[0].Type)
+		assert.Equal(t, `^\d{4}-\d{2}-\d{2}$`, params[0].Pattern)
+		assert.Equal(t, STRING, params[1].Type)
+		assert.Equal(t, "^[0-9-]+$", params[1].Pattern)
+	}
+}
+
+func TestParseRouteParamsUndeclared(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @Summary Show an item
+// @Param id path int true "id of the item"
+// @Success 200 {string} string
+// @Router /items/{name} [get]
+func ShowItem() {
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	params := p.swagger.Paths.Paths["/items/{name}"].Get.Parameters
+	assert.Len(t, params, 2)
+	assert.Equal(t, "name", params[1].Name)
+
+	p = New(SetStrict(true))
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.EqualError(t, err, "path param id is not in route GET /items/{name}")
+}
+
+func TestIsIntegerPattern(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isIntegerPattern("[0-9]+"))
+	assert.True(t, isIntegerPattern(`\d{1,9}`))
+	assert.True(t, isIntegerPattern("^-?[0-9]+$"))
+	assert.False(t, isIntegerPattern("[0-9a-f]+"))
+	assert.False(t, isIntegerPattern("[a-z]+"))
+	assert.False(t, isIntegerPattern(".*"))
+
+	// a minus sign is only allowed as a leading sign
+	assert.True(t, isIntegerPattern("-[0-9]+"))
+	assert.False(t, isIntegerPattern(`\d{4}-\d{2}-\d{2}`))
+	assert.False(t, isIntegerPattern("[0-9-]+"))
+	assert.False(t, isIntegerPattern("^[0-9]+-?$"))
+	assert.False(t, isIntegerPattern("-?-?[0-9]+"))
+}
@@ -1,6 +1,7 @@
 package swag
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-openapi/spec"
@@ -37,6 +38,10 @@ const (
 
 	// IgnoreNameOverridePrefix Prepend to model to avoid renaming based on comment.
 	IgnoreNameOverridePrefix = '$'
+
+	// multipartPackagePath the import path of the package of multipart.FileHeader,
+	// the type gin and net/http bind an uploaded file of a multipart form to.
+	multipartPackagePath = "mime/multipart"
 )
 
 // CheckSchemaType checks if typeName is not a name of primitive type.
@@ -214,6 +219,11 @@ func IsComplexSchema(schema *spec.Schema) bool {
 		return true
 	}
 
+	// a composition, such as a struct composed with its embedded structs, should be complex
+	if len(schema.AllOf) > 0 {
+		return true
+	}
+
 	// a deep array type is complex, how to determine deep? here more than 2 ,for example: [][]object,[][][]int
 	if len(schema.Type) > 2 {
 		return true
@@ -243,6 +253,23 @@ func PrimitiveSchema(refType string) *spec.Schema {
 	return &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{refType}}}
 }
 
+// BinarySchema build a schema of binary data, such as an uploaded file.
+func BinarySchema() *spec.Schema {
+	return spec.StrFmtProperty("binary")
+}
+
+// isMultipartFileHeader whether a type name of a file refers to multipart.FileHeader, whatever its import name.
+func isMultipartFileHeader(typeName string, file *ast.File) bool {
+	pkgName, name, ok := strings.Cut(typeName, ".")
+
+	return ok && name == "FileHeader" && file != nil && importPath(file, pkgName) == multipartPackagePath
+}
+
+// IsBinarySchema whether a schema is a schema of binary data.
+func IsBinarySchema(schema *spec.Schema) bool {
+	return schema.Type.Contains(STRING) && schema.Format == "binary"
+}
+
 // BuildCustomSchema build custom schema specified by tag swaggertype.
 func BuildCustomSchema(types []string) (*spec.Schema, error) {
 	if len(types) == 0 {
@@ -288,6 +315,247 @@ func BuildCustomSchema(types []string) (*spec.Schema, error) {
 	}
 }
 
+// jsonSchemaTypes the types a JSON schema may declare.
+var jsonSchemaTypes = map[string]bool{
+	STRING: true, NUMBER: true, INTEGER: true, BOOLEAN: true, ARRAY: true, OBJECT: true, "null": true,
+}
+
+// openAPIExtraKeywords the keywords of OpenAPI 3 schemas which the Swagger 2 schema keeps in its extra properties.
+var openAPIExtraKeywords = map[string]bool{
+	"nullable": true, "deprecated": true, "writeOnly": true, "const": true, "propertyNames": true, "discriminator": true,
+	"exclusiveMinimum": true, "exclusiveMaximum": true,
+	"contentMediaType": true, "contentEncoding": true, "examples": true, "$schema": true, "$id": true,
+}
+
+// BuildRawSchema build the schema of a JSON schema fragment specified by tag swaggerschema, the fragment is validated.
+func BuildRawSchema(data []byte) (*spec.Schema, error) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	var schema spec.Schema
+	if err := decodeRawSchema(raw, &schema); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	if err := validateRawSchema(&schema, "#"); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	return &schema, nil
+}
+
+// decodeRawSchema decodes a JSON schema fragment and its subschemas. The keywords of OpenAPI 3 which a Swagger 2 schema
+// declares with another type, a discriminator object and a numeric exclusiveMinimum or exclusiveMaximum,
+// are kept in its extra properties.
+func decodeRawSchema(raw interface{}, schema *spec.Schema) error {
+	fragment, ok := raw.(map[string]interface{})
+	if !ok {
+		return remarshal(raw, schema)
+	}
+
+	rest := make(map[string]interface{}, len(fragment))
+	extraProps := make(map[string]interface{})
+	subschemas := make(map[string]interface{})
+	for keyword, value := range fragment {
+		switch keyword {
+		case "discriminator":
+			if _, ok := value.(map[string]interface{}); ok {
+				extraProps[keyword] = value
+				continue
+			}
+		case "exclusiveMinimum", "exclusiveMaximum":
+			if _, ok := value.(float64); ok {
+				extraProps[keyword] = value
+				continue
+			}
+		case "properties", "patternProperties", "definitions":
+			if _, ok := value.(map[string]interface{}); ok {
+				subschemas[keyword] = value
+				continue
+			}
+		case "allOf", "anyOf", "oneOf":
+			if _, ok := value.([]interface{}); ok {
+				subschemas[keyword] = value
+				continue
+			}
+		case "not", "additionalProperties", "additionalItems":
+			if _, ok := value.(map[string]interface{}); ok {
+				subschemas[keyword] = value
+				continue
+			}
+		case "items":
+			switch value.(type) {
+			case map[string]interface{}, []interface{}:
+				subschemas[keyword] = value
+				continue
+			}
+		}
+		rest[keyword] = value
+	}
+
+	if err := remarshal(rest, schema); err != nil {
+		return err
+	}
+
+	for keyword, value := range extraProps {
+		addExtraProp(schema, keyword, value)
+	}
+
+	for keyword, value := range subschemas {
+		switch keyword {
+		case "properties", "patternProperties", "definitions":
+			schemas := make(map[string]spec.Schema)
+			for name, raw := range value.(map[string]interface{}) {
+				var subschema spec.Schema
+				if err := decodeRawSchema(raw, &subschema); err != nil {
+					return err
+				}
+				schemas[name] = subschema
+			}
+			switch keyword {
+			case "properties":
+				schema.Properties = schemas
+			case "patternProperties":
+				schema.PatternProperties = schemas
+			default:
+				schema.Definitions = schemas
+			}
+		case "allOf", "anyOf", "oneOf":
+			schemas, err := decodeRawSchemas(value.([]interface{}))
+			if err != nil {
+				return err
+			}
+			switch keyword {
+			case "allOf":
+				schema.AllOf = schemas
+			case "anyOf":
+				schema.AnyOf = schemas
+			default:
+				schema.OneOf = schemas
+			}
+		case "items":
+			schema.Items = &spec.SchemaOrArray{}
+			if list, ok := value.([]interface{}); ok {
+				schemas, err := decodeRawSchemas(list)
+				if err != nil {
+					return err
+				}
+				schema.Items.Schemas = schemas
+			} else {
+				schema.Items.Schema = &spec.Schema{}
+				if err := decodeRawSchema(value, schema.Items.Schema); err != nil {
+					return err
+				}
+			}
+		default:
+			var subschema spec.Schema
+			if err := decodeRawSchema(value, &subschema); err != nil {
+				return err
+			}
+			switch keyword {
+			case "not":
+				schema.Not = &subschema
+			case "additionalProperties":
+				schema.AdditionalProperties = &spec.SchemaOrBool{Allows: true, Schema: &subschema}
+			default:
+				schema.AdditionalItems = &spec.SchemaOrBool{Allows: true, Schema: &subschema}
+			}
+		}
+	}
+
+	return nil
+}
+
+// decodeRawSchemas decodes a list of JSON schema fragments, see decodeRawSchema.
+func decodeRawSchemas(list []interface{}) ([]spec.Schema, error) {
+	schemas := make([]spec.Schema, len(list))
+	for i, raw := range list {
+		if err := decodeRawSchema(raw, &schemas[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return schemas, nil
+}
+
+// remarshal decodes a decoded JSON value into another type.
+func remarshal(value interface{}, target interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, target)
+}
+
+// validateRawSchema checks the keywords and types of a JSON schema fragment and of its subschemas.
+// Patterns are not checked, they are ECMA-262 regular expressions, whose lookarounds and backreferences regexp rejects.
+func validateRawSchema(schema *spec.Schema, path string) error {
+	for keyword := range schema.ExtraProps {
+		if !openAPIExtraKeywords[keyword] && !strings.HasPrefix(keyword, "x-") {
+			return fmt.Errorf("%s: unknown keyword %s", path, keyword)
+		}
+	}
+
+	for _, schemaType := range schema.Type {
+		if !jsonSchemaTypes[schemaType] {
+			return fmt.Errorf("%s: unknown type %s", path, schemaType)
+		}
+		if schemaType == ARRAY && schema.Items == nil {
+			return fmt.Errorf("%s: missing items of array", path)
+		}
+	}
+
+	if schema.Items != nil {
+		if schema.Items.Schema != nil {
+			if err := validateRawSchema(schema.Items.Schema, path+"/items"); err != nil {
+				return err
+			}
+		}
+		for i := range schema.Items.Schemas {
+			if err := validateRawSchema(&schema.Items.Schemas[i], fmt.Sprintf("%s/items/%d", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		if err := validateRawSchema(schema.AdditionalProperties.Schema, path+"/additionalProperties"); err != nil {
+			return err
+		}
+	}
+
+	if schema.Not != nil {
+		if err := validateRawSchema(schema.Not, path+"/not"); err != nil {
+			return err
+		}
+	}
+
+	for name, subschemas := range map[string][]spec.Schema{"allOf": schema.AllOf, "anyOf": schema.AnyOf, "oneOf": schema.OneOf} {
+		for i := range subschemas {
+			if err := validateRawSchema(&subschemas[i], fmt.Sprintf("%s/%s/%d", path, name, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, property := range schema.Properties {
+		if err := validateRawSchema(&property, path+"/properties/"+name); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range schema.Required {
+		if _, ok := schema.Properties[name]; !ok && len(schema.Properties) > 0 {
+			return fmt.Errorf("%s: required property %s is not declared", path, name)
+		}
+	}
+
+	return nil
+}
+
 // MergeSchema merge schemas
 func MergeSchema(dst *spec.Schema, src *spec.Schema) *spec.Schema {
 	if len(src.Type) > 0 {
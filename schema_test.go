@@ -1,6 +1,10 @@
 package swag
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/go-openapi/spec"
@@ -149,3 +153,123 @@ func TestIsInterfaceLike(t *testing.T) {
 
 	assert.Equal(t, IsInterfaceLike(STRING), false)
 }
+
+// validateInstance validates a JSON instance against a schema referring to definitions,
+// with the keywords of JSON schema the generated schemas use.
+func validateInstance(definitions spec.Definitions, schema spec.Schema, instance string) error {
+	var value interface{}
+	if err := json.Unmarshal([]byte(instance), &value); err != nil {
+		return err
+	}
+
+	return validateValue(definitions, &schema, value, "#")
+}
+
+func validateValue(definitions spec.Definitions, schema *spec.Schema, value interface{}, path string) error {
+	if ref := schema.Ref.String(); ref != "" {
+		definition, ok := definitions[strings.TrimPrefix(ref, "#/definitions/")]
+		if !ok {
+			return fmt.Errorf("%s: unknown $ref %s", path, ref)
+		}
+		schema = &definition
+	}
+
+	if len(schema.Type) > 0 && !schema.Type.Contains(jsonType(value)) &&
+		!(jsonType(value) == INTEGER && schema.Type.Contains(NUMBER)) {
+		return fmt.Errorf("%s: %s is not %v", path, jsonType(value), schema.Type)
+	}
+
+	if len(schema.Enum) > 0 {
+		found := false
+		for _, enum := range schema.Enum {
+			found = found || fmt.Sprint(enum) == fmt.Sprint(value)
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not in the enum", path, value)
+		}
+	}
+
+	if object, ok := value.(map[string]interface{}); ok {
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				return fmt.Errorf("%s: missing required %s", path, name)
+			}
+		}
+		for name, property := range object {
+			if propertySchema, ok := schema.Properties[name]; ok {
+				if err := validateValue(definitions, &propertySchema, property, path+"/"+name); err != nil {
+					return err
+				}
+			} else if additional := schema.AdditionalProperties; additional != nil {
+				if additional.Schema != nil {
+					if err := validateValue(definitions, additional.Schema, property, path+"/"+name); err != nil {
+						return err
+					}
+				} else if !additional.Allows {
+					return fmt.Errorf("%s: additional property %s", path, name)
+				}
+			}
+		}
+	}
+
+	if array, ok := value.([]interface{}); ok && schema.Items != nil && schema.Items.Schema != nil {
+		for i, item := range array {
+			if err := validateValue(definitions, schema.Items.Schema, item, fmt.Sprintf("%s/%d", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range schema.AllOf {
+		if err := validateValue(definitions, &schema.AllOf[i], value, fmt.Sprintf("%s/allOf/%d", path, i)); err != nil {
+			return err
+		}
+	}
+
+	if len(schema.AnyOf) > 0 && countValid(definitions, schema.AnyOf, value, path) == 0 {
+		return fmt.Errorf("%s: no schema of anyOf is valid", path)
+	}
+	if len(schema.OneOf) > 0 && countValid(definitions, schema.OneOf, value, path) != 1 {
+		return fmt.Errorf("%s: not exactly one schema of oneOf is valid", path)
+	}
+
+	if schema.Not != nil && validateValue(definitions, schema.Not, value, path+"/not") == nil {
+		return fmt.Errorf("%s: the schema of not is valid", path)
+	}
+
+	return nil
+}
+
+func countValid(definitions spec.Definitions, schemas []spec.Schema, value interface{}, path string) int {
+	count := 0
+	for i := range schemas {
+		if validateValue(definitions, &schemas[i], value, path) == nil {
+			count++
+		}
+	}
+
+	return count
+}
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return BOOLEAN
+	case float64:
+		if v == float64(int64(v)) {
+			return INTEGER
+		}
+
+		return NUMBER
+	case string:
+		return STRING
+	case []interface{}:
+		return ARRAY
+	case map[string]interface{}:
+		return OBJECT
+	}
+
+	return reflect.TypeOf(value).String()
+}
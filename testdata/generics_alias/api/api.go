@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/venosm/swaggo/testdata/generics_alias/types"
+)
+
+type User struct {
+	Name string `json:"name"`
+}
+
+type Page[T any] = types.Paged[T]
+
+type UserList struct {
+	Users Page[User] `json:"users"`
+}
+
+// @Summary List users
+// @Produce json
+// @Success 200 {object} Page[User]
+// @Success 206 {object} UserList
+// @Router /users [get]
+func GetUsers(w http.ResponseWriter, r *http.Request) {
+	_ = Page[User]{}
+}
+
+// @Summary Get a metric
+// @Produce json
+// @Success 200 {object} types.Metric
+// @Router /metrics [get]
+func GetMetric(w http.ResponseWriter, r *http.Request) {
+	_ = types.Metric[int, string]{}
+}
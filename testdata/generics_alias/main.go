@@ -0,0 +1,18 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/venosm/swaggo/testdata/generics_alias/api"
+)
+
+// @title Swagger Example API
+// @version 1.0
+// @description This is a sample server Petstore server.
+// @host localhost:4000
+// @basePath /api
+func main() {
+	http.HandleFunc("/users/", api.GetUsers)
+	http.HandleFunc("/metrics/", api.GetMetric)
+	http.ListenAndServe(":8080", nil)
+}
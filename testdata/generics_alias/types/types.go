@@ -0,0 +1,17 @@
+package types
+
+type Paged[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+type Metric[N Number, L ~string | ~[]string] struct {
+	Value  N           `json:"value"`
+	Values []N         `json:"values"`
+	Label  L           `json:"label"`
+	Any    interface{} `json:"any"`
+}
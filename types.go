@@ -25,6 +25,9 @@ type TypeSpecDef struct {
 
 	Enums []EnumValue
 
+	// stringValues maps const names to the text printed by the String() method of this type
+	stringValues map[string]string
+
 	// path of package starting from under ${GOPATH}/src or from module path in go.mod
 	PkgPath    string
 	ParentSpec ast.Decl
@@ -32,6 +35,11 @@ type TypeSpecDef struct {
 	SchemaName string
 
 	NotUnique bool
+
+	// genericBase the generic type this type is an instantiation of
+	genericBase *TypeSpecDef
+	// typeArgs the type arguments of a generic instantiation, as written in the source
+	typeArgs []string
 }
 
 // Name the name of the typeSpec.
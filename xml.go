@@ -0,0 +1,186 @@
+package swag
+
+import (
+	"go/ast"
+	"reflect"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	xmlTag = "xml"
+
+	// xmlPackagePath the path of the package of xml.Name
+	xmlPackagePath = "encoding/xml"
+
+	// xmlNameField the field encoding/xml names the element of a struct by, e.g. XMLName xml.Name `xml:"urn:orders order"`
+	xmlNameField = "XMLName"
+
+	// xmlCharDataExtension marks the property encoding/xml writes as the character data of the element
+	xmlCharDataExtension = "x-xml-chardata"
+)
+
+// xmlField the encoding of a struct field by encoding/xml, as its xml tag describes it.
+type xmlField struct {
+	namespace string
+	prefix    string
+	name      string
+	// parents the elements the field is nested in, e.g. items for items>item
+	parents  []string
+	attr     bool
+	chardata bool
+}
+
+// parseXMLTag parses the xml tag of a struct field, e.g. `xml:"urn:orders id,attr"` or `xml:"items>item"`.
+// It returns false if the field has no xml tag or encoding/xml skips it.
+func parseXMLTag(field *ast.Field) (xmlField, bool) {
+	if field.Tag == nil {
+		return xmlField{}, false
+	}
+
+	value, ok := reflect.StructTag(strings.ReplaceAll(field.Tag.Value, "`", "")).Lookup(xmlTag)
+	if !ok || value == "-" {
+		return xmlField{}, false
+	}
+
+	options := strings.Split(value, ",")
+
+	var result xmlField
+	for _, option := range options[1:] {
+		switch option {
+		case "attr":
+			result.attr = true
+		case "chardata", "cdata":
+			result.chardata = true
+		case "innerxml", "comment", "any":
+			// not described by the xml object
+			return xmlField{}, false
+		}
+	}
+
+	name := options[0]
+	if namespace, local, ok := strings.Cut(name, " "); ok {
+		result.namespace, name = namespace, strings.TrimSpace(local)
+	}
+	if path := strings.Split(name, ">"); len(path) > 1 {
+		result.parents, name = path[:len(path)-1], path[len(path)-1]
+	}
+	if prefix, local, ok := strings.Cut(name, ":"); ok {
+		result.prefix, name = prefix, local
+	}
+	result.name = name
+
+	return result, true
+}
+
+// xmlObject returns the xml object of an element or an attribute named name.
+func (field xmlField) xmlObject(name string) *spec.XMLObject {
+	return &spec.XMLObject{
+		Name:      name,
+		Namespace: field.namespace,
+		Prefix:    field.prefix,
+		Attribute: field.attr,
+	}
+}
+
+// parseXMLNameField parses the XMLName field of a struct into the xml object of its schema.
+// The field stays a property, encoding/json encodes it unless its json tag is -.
+func parseXMLNameField(field *ast.Field) (*spec.XMLObject, bool) {
+	if len(field.Names) != 1 || field.Names[0].Name != xmlNameField {
+		return nil, false
+	}
+
+	xmlTagValue, ok := parseXMLTag(field)
+	if !ok || xmlTagValue.name == "" {
+		return nil, true
+	}
+
+	return xmlTagValue.xmlObject(xmlTagValue.name), true
+}
+
+// isXMLNameType whether a type is xml.Name, whatever name a file imports encoding/xml by.
+func isXMLNameType(typeName string, file *ast.File) bool {
+	pkgName, name, ok := strings.Cut(typeName, ".")
+
+	return ok && name == "Name" && file != nil && importPath(file, pkgName) == xmlPackagePath
+}
+
+// xmlNameSchema the schema of xml.Name, which encoding/json encodes as an object of its fields.
+func xmlNameSchema() *spec.Schema {
+	return PrimitiveSchema(OBJECT).
+		SetProperty("Space", *spec.StringProperty()).
+		SetProperty("Local", *spec.StringProperty())
+}
+
+// complementXMLSchema sets the xml object of the property propName of a struct field named goName,
+// which encoding/xml names after its xml tag, or else after the field itself.
+// A wrapped slice, as `xml:"items>item"`, names the wrapper element on the array and the element of each item on its items.
+func (parser *Parser) complementXMLSchema(field *ast.Field, schema *spec.Schema, goName, propName string) {
+	if goName == xmlNameField {
+		// its xml tag names the element of the struct
+		return
+	}
+
+	xmlTagValue, ok := parseXMLTag(field)
+	if !ok {
+		return
+	}
+
+	if xmlTagValue.chardata {
+		schema.AddExtension(xmlCharDataExtension, true)
+
+		return
+	}
+
+	name := xmlTagValue.name
+	if name == "" {
+		name = goName
+	}
+
+	if len(schema.Type) > 0 && schema.Type[0] == ARRAY && schema.Items != nil && schema.Items.Schema != nil {
+		items := *schema.Items.Schema
+		setXMLObject(&items, xmlTagValue.xmlObject(name))
+		schema.Items = &spec.SchemaOrArray{Schema: &items}
+
+		if len(xmlTagValue.parents) == 0 {
+			return
+		}
+
+		if len(xmlTagValue.parents) > 1 {
+			parser.debug.Printf("warning: xml path %s of %s is nested too deep, documenting its innermost wrapper only",
+				strings.Join(append(xmlTagValue.parents, name), ">"), goName)
+		}
+
+		setXMLObject(schema, &spec.XMLObject{
+			Name:    xmlTagValue.parents[len(xmlTagValue.parents)-1],
+			Wrapped: true,
+		})
+
+		return
+	}
+
+	if len(xmlTagValue.parents) > 0 {
+		parser.debug.Printf("warning: xml path %s of %s is not a slice, documenting its innermost element only",
+			strings.Join(append(xmlTagValue.parents, name), ">"), goName)
+	}
+
+	xmlObject := xmlTagValue.xmlObject(name)
+	if xmlObject.Name == propName {
+		xmlObject.Name = ""
+	}
+	if reflect.ValueOf(*xmlObject).IsZero() {
+		return
+	}
+
+	setXMLObject(schema, xmlObject)
+}
+
+// setXMLObject sets the xml object of a schema, a reference is wrapped since its siblings are ignored.
+func setXMLObject(schema *spec.Schema, xmlObject *spec.XMLObject) {
+	if IsRefSchema(schema) {
+		*schema = *(&spec.Schema{}).WithAllOf(*schema)
+	}
+
+	schema.XML = xmlObject
+}
@@ -0,0 +1,140 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseXMLTags(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+import "encoding/xml"
+
+type Item struct {
+	SKU string ` + "`json:\"sku\" xml:\"sku,attr\"`" + `
+}
+
+type Note struct {
+	XMLName xml.Name ` + "`xml:\"note\" json:\"-\"`" + `
+	Lang    string   ` + "`json:\"lang\" xml:\"lang,attr\"`" + `
+	Text    string   ` + "`json:\"text\" xml:\",chardata\"`" + `
+}
+
+type Order struct {
+	XMLName  xml.Name ` + "`xml:\"urn:orders order\"`" + `
+	ID       int      ` + "`json:\"id\" xml:\"id,attr\"`" + `
+	Customer string   ` + "`json:\"customer\" xml:\"cust:Customer\"`" + `
+	Items    []Item   ` + "`json:\"items\" xml:\"items>item\"`" + `
+	Tags     []string ` + "`json:\"tags\" xml:\"tag\"`" + `
+	Note     Note     ` + "`json:\"note\" xml:\"note\"`" + `
+	Internal string   ` + "`json:\"internal\" xml:\"-\"`" + `
+}
+
+// @Success 200 {object} Order
+// @Produce xml
+// @Router /orders [get]
+func Get() {
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	expected := `{
+   "type": "object",
+   "properties": {
+      "customer": {
+         "type": "string",
+         "xml": {
+            "name": "Customer",
+            "prefix": "cust"
+         }
+      },
+      "id": {
+         "type": "integer",
+         "xml": {
+            "attribute": true
+         }
+      },
+      "internal": {
+         "type": "string"
+      },
+      "items": {
+         "type": "array",
+         "items": {
+            "allOf": [
+               {
+                  "$ref": "#/definitions/api.Item"
+               }
+            ],
+            "xml": {
+               "name": "item"
+            }
+         },
+         "xml": {
+            "name": "items",
+            "wrapped": true
+         }
+      },
+      "note": {
+         "$ref": "#/definitions/api.Note"
+      },
+      "tags": {
+         "type": "array",
+         "items": {
+            "type": "string",
+            "xml": {
+               "name": "tag"
+            }
+         }
+      },
+      "xmlname": {
+         "type": "object",
+         "properties": {
+            "Local": {
+               "type": "string"
+            },
+            "Space": {
+               "type": "string"
+            }
+         }
+      }
+   },
+   "xml": {
+      "name": "order",
+      "namespace": "urn:orders"
+   }
+}`
+	b, _ := json.MarshalIndent(p.swagger.Definitions["api.Order"], "", "   ")
+	assert.Equal(t, expected, string(b))
+
+	expected = `{
+   "type": "object",
+   "properties": {
+      "lang": {
+         "type": "string",
+         "xml": {
+            "attribute": true
+         }
+      },
+      "text": {
+         "type": "string",
+         "x-xml-chardata": true
+      }
+   },
+   "xml": {
+      "name": "note"
+   }
+}`
+	b, _ = json.MarshalIndent(p.swagger.Definitions["api.Note"], "", "   ")
+	assert.Equal(t, expected, string(b))
+}